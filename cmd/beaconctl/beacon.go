@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/pkg/beaconclient"
+)
+
+func runSignal(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	f := newFlags("signal", "signal [flags]", stderr, true)
+	message := f.String("m", "", "message sent to the beacon")
+	payloadSize := f.Uint64("payload-size", 0, "size of the payload the beacon attaches to the response")
+	if err := f.parse(args, 0, 0); err != nil {
+		return err
+	}
+
+	p, err := newPrinter(f.output, stdout, "#", "HOSTNAME", "BEACON", "REPLY", "TLS", "LATENCY")
+	if err != nil {
+		return err
+	}
+
+	client, err := f.conn.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	signalOptions := []beaconclient.SignalOption{beaconclient.Message(*message)}
+	if *payloadSize > 0 {
+		signalOptions = append(signalOptions, beaconclient.Payload(*payloadSize, pb.PayloadType_PAYLOAD_TYPE_ZEROS))
+	}
+
+	return f.repeat(ctx, stderr, func(ctx context.Context, i int) error {
+		resp, err := client.Signal(ctx, signalOptions...)
+		if err != nil {
+			return err
+		}
+
+		return p.print(resp.SignalResponse,
+			strconv.Itoa(i+1),
+			orDash(resp.Hostname),
+			orDash(resp.BeaconName),
+			orDash(resp.GetReply()),
+			orDash(resp.GetRequest().GetPeer().GetTls().GetVersion()),
+			formatDuration(resp.Latency),
+		)
+	})
+}
+
+func runStream(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	f := newFlags("stream", "stream [flags]", stderr, false)
+	message := f.String("m", "", "message sent to the beacon")
+	count := f.Uint64("count", 0, "number of responses, 0 until interrupted")
+	every := f.Duration("every", time.Second, "interval between two responses")
+	if err := f.parse(args, 0, 0); err != nil {
+		return err
+	}
+
+	p, err := newPrinter(f.output, stdout, "SEQ", "HOSTNAME", "BEACON", "REPLY", "ELAPSED")
+	if err != nil {
+		return err
+	}
+
+	client, err := f.conn.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel := f.conn.context(ctx)
+	defer cancel()
+
+	err = client.SignalStream(ctx, *count, *every, func(resp *beaconclient.SignalResponse) error {
+		return p.print(resp.SignalResponse,
+			strconv.FormatUint(resp.GetSequence(), 10),
+			orDash(resp.Hostname),
+			orDash(resp.BeaconName),
+			orDash(resp.GetReply()),
+			formatDuration(resp.Latency),
+		)
+	}, beaconclient.Message(*message))
+
+	return streamError(ctx, err)
+}
+
+func runPing(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	f := newFlags("ping", "ping [flags]", stderr, false)
+	count := f.Int("count", 0, "number of pings, 0 until interrupted")
+	every := f.Duration("every", time.Second, "interval between two pings")
+	if err := f.parse(args, 0, 0); err != nil {
+		return err
+	}
+	if *count < 0 {
+		fmt.Fprintf(f.Output(), "-count must not be negative: %d\n", *count)
+		f.Usage()
+		return errFlagsReported
+	}
+
+	p, err := newPrinter(f.output, stdout, "SEQ", "RTT", "OFFSET")
+	if err != nil {
+		return err
+	}
+
+	client, err := f.conn.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel := f.conn.context(ctx)
+	defer cancel()
+
+	stream, err := client.Beacon().Ping(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(*every)
+	defer ticker.Stop()
+
+	for seq := uint64(1); *count == 0 || seq <= uint64(*count); seq++ {
+		if seq > 1 {
+			select {
+			case <-ctx.Done():
+				return streamError(ctx, ctx.Err())
+			case <-ticker.C:
+			}
+		}
+
+		if err := stream.Send(&pb.PingRequest{Sequence: seq, ClientSendTime: timestamppb.Now()}); err != nil {
+			return streamError(ctx, err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return streamError(ctx, err)
+		}
+		received := time.Now()
+
+		// The server processing time is excluded from the round trip, and the
+		// offset assumes the network delay is symmetric.
+		sent := resp.GetClientSendTime().AsTime()
+		serverReceived := resp.GetServerReceiveTime().AsTime()
+		serverSent := resp.GetServerSendTime().AsTime()
+		rtt := received.Sub(sent) - serverSent.Sub(serverReceived)
+		offset := (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2
+
+		if err := p.print(resp,
+			strconv.FormatUint(resp.GetSequence(), 10),
+			formatDuration(rtt),
+			formatDuration(offset),
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// streamError returns the cancellation of ctx instead of the status it
+// caused, so an interrupted stream is not reported as a failure, and a
+// stream ended by -timeout ends the command normally.
+func streamError(ctx context.Context, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(ctx.Err(), context.Canceled):
+		return ctx.Err()
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	// Registers the descriptors of the beacon and health services.
+	_ "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	_ "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const _callUsage = `call [flags] [METHOD [JSON]]
+
+METHOD is the full name of a method, e.g.
+troydai.grpcbeacon.v1.BeaconService/Signal. Without METHOD, the methods
+known to beaconctl are listed. JSON is the request, {} by default, or - to
+read it from stdin. Client streaming methods accept a JSON array of
+requests. The timeout applies to every call, streams included.`
+
+// runCall calls any method compiled into beaconctl, so new beacon RPCs can
+// be called as soon as the generated code is updated.
+func runCall(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	f := newFlags("call", _callUsage, stderr, true)
+	f.output = FormatJSON
+	f.Lookup("o").DefValue = FormatJSON
+	if err := f.parse(args, 0, 2); err != nil {
+		return err
+	}
+
+	if f.NArg() == 0 {
+		for _, name := range listMethods() {
+			fmt.Fprintln(stdout, name)
+		}
+		return nil
+	}
+
+	method, err := findMethod(f.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	input := "{}"
+	switch f.Arg(1) {
+	case "":
+	case "-":
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("fail to read request: %w", err)
+		}
+		input = string(b)
+	default:
+		input = f.Arg(1)
+	}
+
+	requests, err := parseRequests(method, input)
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(f.output, stdout)
+	if err != nil {
+		return err
+	}
+
+	client, err := f.conn.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	fullMethod := fmt.Sprintf("/%s/%s", method.Parent().FullName(), method.Name())
+	return f.repeat(ctx, stderr, func(ctx context.Context, _ int) error {
+		if !method.IsStreamingClient() && !method.IsStreamingServer() {
+			resp := dynamicpb.NewMessage(method.Output())
+			if err := client.Conn().Invoke(ctx, fullMethod, requests[0], resp); err != nil {
+				return err
+			}
+			return p.print(resp)
+		}
+
+		stream, err := client.Conn().NewStream(ctx, &grpc.StreamDesc{
+			ClientStreams: method.IsStreamingClient(),
+			ServerStreams: method.IsStreamingServer(),
+		}, fullMethod)
+		if err != nil {
+			return err
+		}
+		for _, req := range requests {
+			if err := stream.SendMsg(req); err != nil {
+				return err
+			}
+		}
+		if err := stream.CloseSend(); err != nil {
+			return err
+		}
+
+		for {
+			resp := dynamicpb.NewMessage(method.Output())
+			err := stream.RecvMsg(resp)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return streamError(ctx, err)
+			}
+			if err := p.print(resp); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// findMethod resolves Service/Method, or Service.Method.
+func findMethod(name string) (protoreflect.MethodDescriptor, error) {
+	name = strings.TrimPrefix(name, "/")
+	i := strings.LastIndexAny(name, "/.")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid method %q, expected SERVICE/METHOD", name)
+	}
+
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name[:i]))
+	if err != nil {
+		return nil, fmt.Errorf("unknown service %q", name[:i])
+	}
+	service, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", name[:i])
+	}
+
+	method := service.Methods().ByName(protoreflect.Name(name[i+1:]))
+	if method == nil {
+		return nil, fmt.Errorf("unknown method %q of %s", name[i+1:], service.FullName())
+	}
+
+	return method, nil
+}
+
+func listMethods() []string {
+	var names []string
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			service := fd.Services().Get(i)
+			for j := 0; j < service.Methods().Len(); j++ {
+				names = append(names, fmt.Sprintf("%s/%s", service.FullName(), service.Methods().Get(j).Name()))
+			}
+		}
+		return true
+	})
+	sort.Strings(names)
+
+	return names
+}
+
+// parseRequests parses the JSON input into the requests of method.
+func parseRequests(method protoreflect.MethodDescriptor, input string) ([]proto.Message, error) {
+	inputs := []json.RawMessage{json.RawMessage(input)}
+	if method.IsStreamingClient() && strings.HasPrefix(strings.TrimSpace(input), "[") {
+		if err := json.Unmarshal([]byte(input), &inputs); err != nil {
+			return nil, fmt.Errorf("invalid requests: %w", err)
+		}
+	}
+
+	requests := make([]proto.Message, 0, len(inputs))
+	for _, in := range inputs {
+		req := dynamicpb.NewMessage(method.Input())
+		if err := protojson.Unmarshal(in, req); err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+		requests = append(requests, req)
+	}
+	if len(requests) == 0 && !method.IsStreamingClient() {
+		return nil, fmt.Errorf("no request given")
+	}
+
+	return requests, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/troydai/grpcbeacon/pkg/beaconclient"
+)
+
+// connFlags are the flags shared by the commands to reach a beacon.
+type connFlags struct {
+	addr               string
+	useTLS             bool
+	caFile             string
+	certFile           string
+	keyFile            string
+	serverName         string
+	insecureSkipVerify bool
+	headers            beaconclient.Headers
+	timeout            time.Duration
+}
+
+// register adds the connection flags to fs. The timeout applies to every
+// call of unary commands and to the whole command for streams.
+func (c *connFlags) register(fs *flag.FlagSet, timeout time.Duration) {
+	fs.StringVar(&c.addr, "addr", envOr("BEACON_ADDR", "localhost:8080"), "address of the beacon, any gRPC target, e.g. dns:///beacon:8080 or unix:///run/beacon.sock; $BEACON_ADDR")
+	fs.BoolVar(&c.useTLS, "tls", false, "connect over TLS, implied by the other TLS flags")
+	fs.StringVar(&c.caFile, "ca", "", "CA certificate to verify the server with, the system roots by default")
+	fs.StringVar(&c.certFile, "cert", "", "client certificate for mutual TLS")
+	fs.StringVar(&c.keyFile, "key", "", "private key of -cert")
+	fs.StringVar(&c.serverName, "server-name", "", "server name sent through SNI and verified, the host of -addr by default")
+	fs.BoolVar(&c.insecureSkipVerify, "insecure", false, "skip the verification of the server certificate")
+	fs.Var(&c.headers, "H", "request metadata NAME: VALUE, e.g. x-beacon-delay: 1s; repeatable")
+	if timeout > 0 {
+		fs.DurationVar(&c.timeout, "timeout", timeout, "deadline of every call, 0 for none")
+	} else {
+		fs.DurationVar(&c.timeout, "timeout", timeout, "end the stream after this long, 0 to run until interrupted")
+	}
+}
+
+func (c *connFlags) validate() error {
+	if (c.certFile == "") != (c.keyFile == "") {
+		return fmt.Errorf("-cert and -key must be set together")
+	}
+
+	return nil
+}
+
+// dial creates a client of the beacon.
+func (c *connFlags) dial() (*beaconclient.Client, error) {
+	var opts []beaconclient.Option
+	if c.useTLS || c.caFile != "" || c.certFile != "" || c.serverName != "" || c.insecureSkipVerify {
+		opts = append(opts, beaconclient.WithTLS(beaconclient.TLS{
+			CAFile:             c.caFile,
+			CertFile:           c.certFile,
+			KeyFile:            c.keyFile,
+			ServerName:         c.serverName,
+			InsecureSkipVerify: c.insecureSkipVerify,
+		}))
+	}
+
+	return beaconclient.Dial(c.addr, opts...)
+}
+
+// context returns the context of a call, carrying the metadata and the
+// deadline.
+func (c *connFlags) context(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx := metadata.NewOutgoingContext(parent, c.headers.MD())
+
+	if c.timeout > 0 {
+		return context.WithTimeout(ctx, c.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
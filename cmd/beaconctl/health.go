@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+)
+
+func runHealth(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "check":
+			return runHealthCheck(ctx, args[1:], stdout, stderr)
+		case "watch":
+			return runHealthWatch(ctx, args[1:], stdout, stderr)
+		}
+	}
+
+	fmt.Fprint(stderr, "Usage: beaconctl health check|watch [flags] [SERVICE]\n")
+	return errFlagsReported
+}
+
+func runHealthCheck(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	f := newFlags("health check", "health check [flags] [SERVICE]", stderr, true)
+	if err := f.parse(args, 0, 1); err != nil {
+		return err
+	}
+	service := f.Arg(0)
+
+	p, err := newPrinter(f.output, stdout, "SERVICE", "STATUS", "LATENCY")
+	if err != nil {
+		return err
+	}
+
+	client, err := f.conn.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	req := &healthpb.HealthCheckRequest{Service: service}
+
+	return f.repeat(ctx, stderr, func(ctx context.Context, _ int) error {
+		start := time.Now()
+		resp, err := client.Health().Check(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		return p.print(resp, serviceName(service), resp.GetStatus().String(), formatDuration(time.Since(start)))
+	})
+}
+
+func runHealthWatch(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	f := newFlags("health watch", "health watch [flags] [SERVICE]", stderr, false)
+	if err := f.parse(args, 0, 1); err != nil {
+		return err
+	}
+	service := f.Arg(0)
+
+	p, err := newPrinter(f.output, stdout, "TIME", "SERVICE", "STATUS")
+	if err != nil {
+		return err
+	}
+
+	client, err := f.conn.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel := f.conn.context(ctx)
+	defer cancel()
+
+	stream, err := client.Health().Watch(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return streamError(ctx, err)
+		}
+
+		if err := p.print(resp, time.Now().Format(time.RFC3339), serviceName(service), resp.GetStatus().String()); err != nil {
+			return err
+		}
+	}
+}
+
+func runAdmin(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "list":
+			return runAdminList(ctx, args[1:], stdout, stderr)
+		case "set":
+			return runAdminSet(ctx, args[1:], stdout, stderr)
+		}
+	}
+
+	fmt.Fprint(stderr, "Usage: beaconctl admin list|set [flags] [SERVICE STATUS]\n")
+	return errFlagsReported
+}
+
+// adminFlags adds the admin token to the flags of an admin command.
+func adminFlags(name, usage string, stderr io.Writer) (*flags, *string) {
+	f := newFlags(name, usage, stderr, true)
+	token := f.String("token", envOr("BEACON_ADMIN_TOKEN", ""), "admin token sent as a bearer token; $BEACON_ADMIN_TOKEN")
+	return f, token
+}
+
+func (f *flags) authorize(token string) {
+	if token != "" {
+		f.conn.headers = append(f.conn.headers, "authorization: Bearer "+token)
+	}
+}
+
+func runAdminList(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	f, token := adminFlags("admin list", "admin list [flags]", stderr)
+	if err := f.parse(args, 0, 0); err != nil {
+		return err
+	}
+	f.authorize(*token)
+
+	p, err := newPrinter(f.output, stdout, "SERVICE", "STATUS")
+	if err != nil {
+		return err
+	}
+
+	client, err := f.conn.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return f.repeat(ctx, stderr, func(ctx context.Context, _ int) error {
+		resp, err := client.Admin().ListServingStatus(ctx, &pb.ListServingStatusRequest{})
+		if err != nil {
+			return err
+		}
+
+		services := make([]string, 0, len(resp.GetStatuses()))
+		for service := range resp.GetStatuses() {
+			services = append(services, service)
+		}
+		sort.Strings(services)
+
+		rows := make([][]string, 0, len(services))
+		for _, service := range services {
+			rows = append(rows, []string{serviceName(service), servingStatusName(resp.GetStatuses()[service])})
+		}
+
+		return p.printRows(resp, rows)
+	})
+}
+
+func runAdminSet(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	f, token := adminFlags("admin set", "admin set [flags] SERVICE STATUS\n\nSERVICE is \"\" for the overall status, STATUS is serving, not-serving or\nservice-unknown.", stderr)
+	if err := f.parse(args, 2, 2); err != nil {
+		return err
+	}
+	f.authorize(*token)
+
+	service := f.Arg(0)
+	st, err := parseServingStatus(f.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(f.output, stdout, "SERVICE", "STATUS", "PREVIOUS")
+	if err != nil {
+		return err
+	}
+
+	client, err := f.conn.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	req := &pb.SetServingStatusRequest{Service: service, Status: st}
+
+	return f.repeat(ctx, stderr, func(ctx context.Context, _ int) error {
+		resp, err := client.Admin().SetServingStatus(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		return p.print(resp, serviceName(service), servingStatusName(st), servingStatusName(resp.GetPreviousStatus()))
+	})
+}
+
+// parseServingStatus accepts serving, not-serving and service-unknown, as
+// well as the enum names.
+func parseServingStatus(s string) (pb.ServingStatus, error) {
+	name := strings.ToUpper(strings.ReplaceAll(s, "-", "_"))
+	if !strings.HasPrefix(name, "SERVING_STATUS_") {
+		name = "SERVING_STATUS_" + name
+	}
+
+	if v, ok := pb.ServingStatus_value[name]; ok && v != int32(pb.ServingStatus_SERVING_STATUS_UNSPECIFIED) {
+		return pb.ServingStatus(v), nil
+	}
+	return 0, fmt.Errorf("invalid serving status %q, expected serving, not-serving or service-unknown", s)
+}
+
+func servingStatusName(st pb.ServingStatus) string {
+	return strings.TrimPrefix(st.String(), "SERVING_STATUS_")
+}
+
+// serviceName names the overall status, which has no service name.
+func serviceName(service string) string {
+	if service == "" {
+		return "(overall)"
+	}
+	return service
+}
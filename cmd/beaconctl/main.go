@@ -0,0 +1,170 @@
+// Command beaconctl calls and inspects grpcbeacon servers.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+const _usage = `Usage: beaconctl <command> [flags] [args]
+
+Commands:
+  signal               call BeaconService/Signal
+  stream               call BeaconService/SignalStream and print the responses
+  ping                 measure round trips and clock offset with BeaconService/Ping
+  health check [SVC]   call grpc.health.v1.Health/Check
+  health watch [SVC]   call grpc.health.v1.Health/Watch and print the changes
+  admin list           list the serving statuses through the AdminService
+  admin set SVC STATUS set a serving status through the AdminService
+  call METHOD [JSON]   call any beacon method by its full name with a JSON
+                       request, or list the methods without METHOD
+
+Run beaconctl <command> -h for the flags of a command. Faults are injected
+through metadata, e.g. -H 'x-beacon-status-code: 14'.
+`
+
+// errFlagsReported is returned when the flag set already reported an
+// invalid flag.
+var errFlagsReported = errors.New("invalid flags")
+
+type command func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+
+var _commands = map[string]command{
+	"signal": runSignal,
+	"stream": runStream,
+	"ping":   runPing,
+	"health": runHealth,
+	"admin":  runAdmin,
+	"call":   runCall,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command line and returns the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		fmt.Fprint(stderr, _usage)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	cmd, ok := _commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], _usage)
+		return 2
+	}
+
+	err := cmd(ctx, args[1:], stdout, stderr)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errFlagsReported):
+		return 2
+	case errors.Is(err, context.Canceled) && ctx.Err() != nil:
+		// Interrupted by the user.
+		return 0
+	default:
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+}
+
+// flags are the flags shared by the commands.
+type flags struct {
+	*flag.FlagSet
+
+	conn     connFlags
+	output   string
+	count    int
+	interval time.Duration
+}
+
+// newFlags creates the flag set of a command. Unary commands repeat their
+// call and get a per call timeout, streaming commands do not.
+func newFlags(name, usage string, stderr io.Writer, unary bool) *flags {
+	f := &flags{FlagSet: flag.NewFlagSet(name, flag.ContinueOnError)}
+	f.SetOutput(stderr)
+	f.Usage = func() {
+		fmt.Fprintf(f.Output(), "Usage: beaconctl %s\n\nFlags:\n", usage)
+		f.PrintDefaults()
+	}
+
+	timeout := time.Duration(0)
+	if unary {
+		timeout = 10 * time.Second
+		f.IntVar(&f.count, "n", 1, "number of calls")
+		f.DurationVar(&f.interval, "interval", 0, "wait between two calls")
+	}
+	f.conn.register(f.FlagSet, timeout)
+	f.StringVar(&f.output, "o", FormatTable, "output format: table, json or text")
+
+	return f
+}
+
+// parse parses args and checks the number of positional arguments.
+func (f *flags) parse(args []string, minArgs, maxArgs int) error {
+	if err := f.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errFlagsReported
+	}
+	if f.NArg() < minArgs || f.NArg() > maxArgs {
+		fmt.Fprintf(f.Output(), "unexpected number of arguments: %s\n", strings.Join(f.Args(), " "))
+		f.Usage()
+		return errFlagsReported
+	}
+	if err := f.conn.validate(); err != nil {
+		return err
+	}
+	if f.count < 0 {
+		return fmt.Errorf("-n must not be negative")
+	}
+
+	return nil
+}
+
+// repeat makes -n calls, -interval apart. A failed call is reported to
+// stderr and the following calls are still made.
+func (f *flags) repeat(ctx context.Context, stderr io.Writer, call func(ctx context.Context, i int) error) error {
+	failed := 0
+	for i := 0; i < f.count; i++ {
+		if i > 0 && f.interval > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(f.interval):
+			}
+		}
+
+		callCtx, cancel := f.conn.context(ctx)
+		err := call(callCtx, i)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Fprintf(stderr, "call %d: %v\n", i+1, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d calls failed", failed, f.count)
+	}
+	return nil
+}
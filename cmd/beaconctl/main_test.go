@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+func TestBeaconctl(t *testing.T) {
+	var server *rpc.Server
+	app := fxtest.New(t,
+		fx.Populate(&server),
+		fx.Provide(func() settings.Configuration {
+			return settings.Configuration{
+				Name:    "ctl-beacon",
+				Address: "127.0.0.1",
+				Admin:   &settings.Admin{Enabled: true, Token: "secret"},
+			}
+		}),
+		fx.Provide(func() settings.Environment { return settings.Environment{HostName: "ctl-host"} }),
+		logging.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	t.Setenv("BEACON_ADDR", server.Addrs()[0].String())
+	beaconctl := func(t *testing.T, args ...string) (int, string, string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var stdout, stderr bytes.Buffer
+		code := run(ctx, args, &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	t.Run("signal prints a row per call", func(t *testing.T) {
+		code, stdout, stderr := beaconctl(t, "signal", "-n", "2", "-m", "hello")
+		require.Equal(t, 0, code, stderr)
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, []string{"#", "HOSTNAME", "BEACON", "REPLY"}, strings.Fields(lines[0])[:4])
+		assert.Equal(t, []string{"2", "ctl-host", "ctl-beacon"}, strings.Fields(lines[2])[:3])
+	})
+
+	t.Run("signal prints JSON lines", func(t *testing.T) {
+		code, stdout, stderr := beaconctl(t, "signal", "-o", "json", "-H", "x-test: value")
+		require.Equal(t, 0, code, stderr)
+
+		var resp struct {
+			Details map[string]string
+			Request struct {
+				Headers map[string]struct{ Values []string }
+			}
+		}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &resp))
+		assert.Equal(t, "ctl-beacon", resp.Details["BeaconName"])
+		assert.Equal(t, []string{"value"}, resp.Request.Headers["x-test"].Values)
+	})
+
+	t.Run("failed calls are reported", func(t *testing.T) {
+		code, _, stderr := beaconctl(t, "signal", "-n", "2", "-H", "x-beacon-status-code: 14")
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "code = Unavailable")
+		assert.Contains(t, stderr, "2 of 2 calls failed")
+	})
+
+	t.Run("admin set changes the health status", func(t *testing.T) {
+		const service = "troydai.grpcbeacon.v1.BeaconService"
+
+		code, _, stderr := beaconctl(t, "admin", "set", "-token", "secret", service, "not-serving")
+		require.Equal(t, 0, code, stderr)
+		t.Cleanup(func() { beaconctl(t, "admin", "set", "-token", "secret", service, "serving") })
+
+		code, stdout, stderr := beaconctl(t, "health", "check", "-o", "text", service)
+		require.Equal(t, 0, code, stderr)
+		assert.Contains(t, stdout, "NOT_SERVING")
+
+		code, _, stderr = beaconctl(t, "admin", "list")
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "Unauthenticated")
+	})
+
+	t.Run("call invokes methods by name", func(t *testing.T) {
+		code, stdout, stderr := beaconctl(t, "call", "troydai.grpcbeacon.v1.BeaconService/SignalStream", `{"count": 2, "interval": "0.01s"}`)
+		require.Equal(t, 0, code, stderr)
+		assert.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 2)
+
+		code, _, stderr = beaconctl(t, "call", "troydai.grpcbeacon.v1.BeaconService/Unknown")
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "unknown method")
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		code, _, _ := beaconctl(t, "signal", "-o", "yaml")
+		assert.Equal(t, 1, code)
+
+		code, _, _ = beaconctl(t, "signal", "-cert", "client.crt.pem")
+		assert.Equal(t, 1, code)
+
+		code, _, stderr := beaconctl(t, "ping", "-count", "-1")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, "-count must not be negative")
+
+		code, _, _ = beaconctl(t, "unknown")
+		assert.Equal(t, 2, code)
+	})
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// Output formats.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatText  = "text"
+)
+
+// printer writes responses in the selected format: a row per response for
+// table, a JSON object per line for json, and the proto text format for
+// text.
+type printer struct {
+	format string
+	out    io.Writer
+	table  *tabwriter.Writer
+	header []string
+}
+
+func newPrinter(format string, out io.Writer, header ...string) (*printer, error) {
+	p := &printer{format: format, out: out, header: header}
+
+	switch format {
+	case FormatTable:
+		if len(header) == 0 {
+			return nil, fmt.Errorf("output format %s is not supported by this command", format)
+		}
+		// Rows are flushed one by one so streams print as they go, the minimum
+		// width keeps the columns mostly aligned.
+		p.table = tabwriter.NewWriter(out, 10, 0, 2, ' ', 0)
+	case FormatJSON, FormatText:
+	default:
+		return nil, fmt.Errorf("unknown output format %q, expected table, json or text", format)
+	}
+
+	return p, nil
+}
+
+// print writes m, or row when the format is table.
+func (p *printer) print(m proto.Message, row ...string) error {
+	return p.printRows(m, [][]string{row})
+}
+
+// printRows writes m, or rows when the format is table.
+func (p *printer) printRows(m proto.Message, rows [][]string) error {
+	switch p.format {
+	case FormatTable:
+		if p.header != nil {
+			fmt.Fprintln(p.table, strings.Join(p.header, "\t"))
+			p.header = nil
+		}
+		for _, row := range rows {
+			fmt.Fprintln(p.table, strings.Join(row, "\t"))
+		}
+		return p.table.Flush()
+	case FormatJSON:
+		b, err := protojson.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("fail to marshal response: %w", err)
+		}
+		_, err = fmt.Fprintf(p.out, "%s\n", b)
+		return err
+	default:
+		b, err := prototext.MarshalOptions{Multiline: true}.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("fail to marshal response: %w", err)
+		}
+		_, err = fmt.Fprintf(p.out, "%s\n", b)
+		return err
+	}
+}
+
+// orDash returns "-" for empty cells.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(10 * time.Microsecond).String()
+}
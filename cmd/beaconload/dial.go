@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+
+	"github.com/troydai/grpcbeacon/pkg/beaconclient"
+)
+
+// Resolvers selectable with -resolver.
+const (
+	ResolverDNS         = "dns"
+	ResolverPassthrough = "passthrough"
+	ResolverStatic      = "static"
+)
+
+// dialOptions are the flags that control how the connections are made.
+type dialOptions struct {
+	addr               string
+	resolver           string
+	lbPolicy           string
+	useTLS             bool
+	caFile             string
+	certFile           string
+	keyFile            string
+	serverName         string
+	insecureSkipVerify bool
+}
+
+// dial creates a client. Every client has its own connection and
+// subchannels, so n clients spread the load over the backends as n
+// processes would.
+func (o *dialOptions) dial() (*beaconclient.Client, error) {
+	var opts []beaconclient.Option
+	if o.useTLS || o.caFile != "" || o.certFile != "" || o.serverName != "" || o.insecureSkipVerify {
+		opts = append(opts, beaconclient.WithTLS(beaconclient.TLS{
+			CAFile:             o.caFile,
+			CertFile:           o.certFile,
+			KeyFile:            o.keyFile,
+			ServerName:         o.serverName,
+			InsecureSkipVerify: o.insecureSkipVerify,
+		}))
+	}
+	if o.lbPolicy != "" {
+		opts = append(opts, beaconclient.WithLoadBalancingPolicy(o.lbPolicy))
+	}
+
+	target := o.addr
+	switch o.resolver {
+	case "":
+	case ResolverDNS, ResolverPassthrough:
+		if !strings.Contains(target, "://") && !strings.HasPrefix(target, o.resolver+":") {
+			target = o.resolver + ":///" + target
+		}
+	case ResolverStatic:
+		// The addresses are resolved once, as given, so every backend is known
+		// to the balancer without DNS.
+		var addrs []resolver.Address
+		for _, a := range strings.Split(o.addr, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				addrs = append(addrs, resolver.Address{Addr: a})
+			}
+		}
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("-addr has no address to resolve")
+		}
+		r := manual.NewBuilderWithScheme("beaconload")
+		r.InitialState(resolver.State{Addresses: addrs})
+		// The authority defaults to the endpoint of the target, which is no
+		// host name, so the host of the first address is verified with TLS
+		// unless -server-name is set.
+		host, _, err := net.SplitHostPort(addrs[0].Addr)
+		if err != nil {
+			host = addrs[0].Addr
+		}
+		opts = append(opts, beaconclient.WithDialOptions(grpc.WithResolvers(r), grpc.WithAuthority(host)))
+		target = r.Scheme() + ":///static"
+	default:
+		return nil, fmt.Errorf("unknown resolver %q, expected dns, passthrough or static", o.resolver)
+	}
+
+	return beaconclient.Dial(target, opts...)
+}
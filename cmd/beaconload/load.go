@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/troydai/grpcbeacon/pkg/beaconclient"
+)
+
+// loadOptions control the load generated against the beacon.
+type loadOptions struct {
+	// qps caps the rate of calls across all workers. Zero runs closed loop:
+	// every worker sends its next call as soon as the previous one returns.
+	qps         float64
+	concurrency int
+	duration    time.Duration
+	timeout     time.Duration
+	headers     metadata.MD
+	signal      []beaconclient.SignalOption
+}
+
+// result is the outcome of a call.
+type result struct {
+	latency time.Duration
+	code    codes.Code
+	backend backend
+}
+
+// backend identifies the beacon that answered through the details of its
+// response.
+type backend struct {
+	Hostname   string `json:"hostname"`
+	BeaconName string `json:"beacon_name"`
+}
+
+// pacer hands out the start times of the calls, 1/qps apart.
+type pacer struct {
+	mu       sync.Mutex
+	next     time.Time
+	interval time.Duration
+}
+
+func newPacer(qps float64, start time.Time) *pacer {
+	if qps <= 0 {
+		return nil
+	}
+	return &pacer{next: start, interval: time.Duration(float64(time.Second) / qps)}
+}
+
+// wait blocks until the next slot and returns its time. The latency of a
+// call is measured from its slot, so a call delayed by the slow ones before
+// it counts the time it waited instead of hiding it, i.e. the coordinated
+// omission. A nil pacer never waits and returns the current time.
+func (p *pacer) wait(ctx context.Context) (time.Time, error) {
+	if p == nil {
+		return time.Now(), ctx.Err()
+	}
+
+	p.mu.Lock()
+	slot := p.next
+	p.next = p.next.Add(p.interval)
+	p.mu.Unlock()
+
+	d := time.Until(slot)
+	if d <= 0 {
+		return slot, ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return slot, ctx.Err()
+	case <-timer.C:
+		return slot, nil
+	}
+}
+
+// generate calls Signal over conns until the duration elapses or ctx is
+// cancelled, spreading the workers over the connections, and returns the
+// results and the time the load ran for.
+func generate(ctx context.Context, clients []*beaconclient.Client, opts loadOptions) ([]result, time.Duration) {
+	// The calls in flight when the duration elapses are completed and
+	// counted. Bounding them by the run would propagate its deadline to the
+	// server, which may expire it first and report DeadlineExceeded.
+	runCtx, cancel := context.WithTimeout(ctx, opts.duration)
+	defer cancel()
+
+	start := time.Now()
+	p := newPacer(opts.qps, start)
+
+	var (
+		mu      sync.Mutex
+		results []result
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < opts.concurrency; i++ {
+		client := clients[i%len(clients)]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var local []result
+			for {
+				slot, err := p.wait(runCtx)
+				if err != nil {
+					break
+				}
+				r, ok := call(ctx, client, opts, slot)
+				if !ok {
+					break
+				}
+				local = append(local, r)
+			}
+
+			mu.Lock()
+			results = append(results, local...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return results, time.Since(start)
+}
+
+// call makes a Signal call scheduled at start and measures its latency from
+// then. It returns false when the call was cut short by the cancellation of
+// ctx, in which case it is not counted.
+func call(ctx context.Context, client *beaconclient.Client, opts loadOptions, start time.Time) (result, bool) {
+	callCtx := metadata.NewOutgoingContext(ctx, opts.headers)
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(callCtx, opts.timeout)
+		defer cancel()
+	}
+
+	resp, err := client.Signal(callCtx, opts.signal...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return result{}, false
+		}
+		return result{latency: time.Since(start), code: status.Code(err)}, true
+	}
+
+	return result{
+		latency: time.Since(start),
+		code:    codes.OK,
+		backend: backend{Hostname: resp.Hostname, BeaconName: resp.BeaconName},
+	}, true
+}
@@ -0,0 +1,132 @@
+// Command beaconload generates load against grpcbeacon servers and reports
+// the latency, the status codes and how the calls were spread over the
+// backends, e.g. to check the fairness of a load balancer.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/troydai/grpcbeacon/pkg/beaconclient"
+)
+
+const _usage = `Usage: beaconload [flags]
+
+Calls BeaconService/Signal for -duration, from -concurrency workers spread
+over -connections connections, at up to -qps calls per second or as fast as
+the calls return when -qps is 0. The report is written once the run ends or
+is interrupted.
+
+Flags:
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command line and returns the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("beaconload", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), _usage)
+		fs.PrintDefaults()
+	}
+
+	var (
+		dial    dialOptions
+		load    loadOptions
+		conns   int
+		message string
+		format  string
+		headers beaconclient.Headers
+	)
+	fs.StringVar(&dial.addr, "addr", "localhost:8080", "gRPC target of the beacon, a comma separated list of addresses with -resolver static")
+	fs.StringVar(&dial.resolver, "resolver", "", "dns, passthrough or static, the scheme of -addr by default")
+	fs.StringVar(&dial.lbPolicy, "lb-policy", "", "load balancing policy, e.g. pick_first or round_robin, the service config of the target by default")
+	fs.BoolVar(&dial.useTLS, "tls", false, "connect over TLS, implied by the other TLS flags")
+	fs.StringVar(&dial.caFile, "ca", "", "CA certificate to verify the server with, the system roots by default")
+	fs.StringVar(&dial.certFile, "cert", "", "client certificate for mutual TLS")
+	fs.StringVar(&dial.keyFile, "key", "", "private key of -cert")
+	fs.StringVar(&dial.serverName, "server-name", "", "server name sent through SNI and verified")
+	fs.BoolVar(&dial.insecureSkipVerify, "insecure", false, "skip the verification of the server certificate")
+	fs.Float64Var(&load.qps, "qps", 0, "target calls per second across all workers, 0 for closed loop")
+	fs.IntVar(&load.concurrency, "concurrency", 10, "number of workers, each with at most one call in flight")
+	fs.DurationVar(&load.duration, "duration", 10*time.Second, "how long the load runs")
+	fs.DurationVar(&load.timeout, "timeout", 10*time.Second, "deadline of every call, 0 for none")
+	fs.IntVar(&conns, "connections", 1, "number of client connections")
+	fs.StringVar(&message, "m", "", "message sent to the beacon")
+	fs.Var(&headers, "H", "request metadata NAME: VALUE, e.g. x-beacon-delay: 10ms; repeatable")
+	fs.StringVar(&format, "o", FormatText, "report format: text, json or csv")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		return 2
+	}
+
+	if err := validate(load, conns, format); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	load.signal = []beaconclient.SignalOption{beaconclient.Message(message)}
+	load.headers = headers.MD()
+
+	clients := make([]*beaconclient.Client, 0, conns)
+	defer func() {
+		for _, c := range clients {
+			c.Close()
+		}
+	}()
+	for i := 0; i < conns; i++ {
+		c, err := dial.dial()
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		clients = append(clients, c)
+	}
+
+	results, elapsed := generate(ctx, clients, load)
+	if err := newReport(dial.addr, elapsed, results).write(stdout, format); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func validate(load loadOptions, conns int, format string) error {
+	switch {
+	case load.qps < 0:
+		return fmt.Errorf("-qps must not be negative")
+	case load.concurrency < 1:
+		return fmt.Errorf("-concurrency must be positive")
+	case load.duration <= 0:
+		return fmt.Errorf("-duration must be positive")
+	case conns < 1:
+		return fmt.Errorf("-connections must be positive")
+	}
+
+	switch format {
+	case FormatText, FormatJSON, FormatCSV:
+		return nil
+	}
+	return fmt.Errorf("unknown report format %q, expected text, json or csv", format)
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/grpc/codes"
+
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/certgen"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+func startBeacon(t *testing.T, name string, tlsConfig *settings.TLSConfiguration) string {
+	var server *rpc.Server
+	app := fxtest.New(t,
+		fx.Populate(&server),
+		fx.Provide(func() settings.Configuration {
+			return settings.Configuration{Name: name, Address: "127.0.0.1", TLS: tlsConfig}
+		}),
+		fx.Provide(func() settings.Environment { return settings.Environment{HostName: name + "-host"} }),
+		fx.NopLogger,
+		logging.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	return server.Addrs()[0].String()
+}
+
+func TestBeaconload(t *testing.T) {
+	start := func(name string) string { return startBeacon(t, name, nil) }
+
+	addrs := start("alpha") + "," + start("beta")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	code := run(ctx, []string{
+		"-addr", addrs,
+		"-resolver", "static",
+		"-lb-policy", "round_robin",
+		"-qps", "100",
+		"-duration", "500ms",
+		"-H", "x-beacon-delay: 1ms",
+		"-o", "json",
+	}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	var r report
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &r))
+	assert.InDelta(t, 50, r.Requests, 5)
+	assert.Equal(t, map[string]int{"OK": r.Requests}, r.Codes)
+	assert.GreaterOrEqual(t, r.Latency.Min, 1.0)
+
+	require.Len(t, r.Backends, 2)
+	for _, b := range r.Backends {
+		assert.Contains(t, []string{"alpha", "beta"}, b.BeaconName)
+		assert.Equal(t, b.BeaconName+"-host", b.Hostname)
+		assert.InDelta(t, 0.5, b.Share, 0.05)
+	}
+}
+
+func TestBeaconloadCoordinatedOmission(t *testing.T) {
+	addr := startBeacon(t, "alpha", nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The calls take 50ms while the slots are 10ms apart, so every call
+	// waits longer than the previous one for its slot.
+	var stdout, stderr bytes.Buffer
+	code := run(ctx, []string{
+		"-addr", addr,
+		"-qps", "100",
+		"-concurrency", "1",
+		"-duration", "500ms",
+		"-H", "x-beacon-delay: 50ms",
+		"-o", "json",
+	}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	var r report
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &r))
+	assert.GreaterOrEqual(t, r.Latency.Min, 50.0)
+	assert.Greater(t, r.Latency.Max, 200.0, "the latency includes the wait for the slot")
+}
+
+func TestBeaconloadStaticTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := certgen.NewCA(certgen.Options{CommonName: "beaconload CA"})
+	require.NoError(t, err)
+	server, err := ca.Issue(certgen.Options{CommonName: "127.0.0.1", SANs: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.NoError(t, ca.WriteFiles(dir, "ca"))
+	require.NoError(t, server.WriteFiles(dir, "server"))
+
+	addr := startBeacon(t, "alpha", &settings.TLSConfiguration{
+		Enabled:      true,
+		KeyFilePath:  filepath.Join(dir, "server.key.pem"),
+		CertFilePath: filepath.Join(dir, "server.crt.pem"),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The server certificate is verified against the host of the address.
+	var stdout, stderr bytes.Buffer
+	code := run(ctx, []string{
+		"-addr", addr,
+		"-resolver", "static",
+		"-ca", filepath.Join(dir, "ca.crt.pem"),
+		"-duration", "100ms",
+		"-concurrency", "1",
+		"-o", "json",
+	}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	var r report
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &r))
+	assert.Positive(t, r.Requests)
+	assert.Equal(t, map[string]int{"OK": r.Requests}, r.Codes)
+}
+
+func TestReport(t *testing.T) {
+	var results []result
+	for i := 1; i <= 100; i++ {
+		r := result{latency: time.Duration(i) * time.Millisecond, code: codes.OK, backend: backend{Hostname: "h1", BeaconName: "b"}}
+		if i%4 == 0 {
+			r.backend.Hostname = "h2"
+		}
+		if i == 100 {
+			r = result{latency: 10 * time.Second, code: codes.DeadlineExceeded}
+		}
+		results = append(results, r)
+	}
+
+	r := newReport("target", 2*time.Second, results)
+
+	assert.Equal(t, 100, r.Requests)
+	assert.Equal(t, 50.0, r.QPS)
+	assert.Equal(t, 1.0, r.Latency.Min)
+	assert.Equal(t, 50.0, r.Latency.P50)
+	assert.Equal(t, 99.0, r.Latency.P99)
+	assert.Equal(t, 10000.0, r.Latency.Max)
+	assert.Equal(t, map[string]int{"OK": 99, "DeadlineExceeded": 1}, r.Codes)
+
+	require.Len(t, r.Backends, 2)
+	assert.Equal(t, backend{Hostname: "h1", BeaconName: "b"}, r.Backends[0].backend)
+	assert.Equal(t, 75, r.Backends[0].Count)
+	assert.InDelta(t, 75.0/99, r.Backends[0].Share, 1e-9)
+
+	total := 0
+	for _, b := range r.Histogram {
+		total += b.Count
+	}
+	assert.Equal(t, 100, total)
+	assert.Nil(t, r.Histogram[len(r.Histogram)-1].UpperBound)
+	assert.Equal(t, 1, r.Histogram[len(r.Histogram)-1].Count)
+
+	t.Run("csv", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, r.write(&b, FormatCSV))
+
+		rows, err := csv.NewReader(&b).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"section", "name", "value"}, rows[0])
+		assert.Contains(t, rows, []string{"latency_ms", "p50", "50"})
+		assert.Contains(t, rows, []string{"backend", "h2/b", "24"})
+		assert.Contains(t, rows, []string{"code", "DeadlineExceeded", "1"})
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, r.write(&b, FormatText))
+		assert.Contains(t, b.String(), "h2")
+		assert.Contains(t, b.String(), "DeadlineExceeded")
+	})
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Report formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// _bucketBounds are the upper bounds of the latency histogram buckets. The
+// last bucket is unbounded.
+var _bucketBounds = []time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+type (
+	report struct {
+		Target    string         `json:"target"`
+		Duration  float64        `json:"duration_seconds"`
+		Requests  int            `json:"requests"`
+		QPS       float64        `json:"qps"`
+		Latency   latencyStats   `json:"latency_ms"`
+		Histogram []bucket       `json:"histogram"`
+		Codes     map[string]int `json:"codes"`
+		Backends  []backendCount `json:"backends"`
+	}
+
+	// latencyStats are in milliseconds.
+	latencyStats struct {
+		Min  float64 `json:"min"`
+		Mean float64 `json:"mean"`
+		P50  float64 `json:"p50"`
+		P90  float64 `json:"p90"`
+		P95  float64 `json:"p95"`
+		P99  float64 `json:"p99"`
+		P999 float64 `json:"p99_9"`
+		Max  float64 `json:"max"`
+	}
+
+	bucket struct {
+		// UpperBound is in milliseconds, +Inf for the last bucket, which JSON
+		// encodes as null.
+		UpperBound *float64 `json:"le_ms"`
+		Count      int      `json:"count"`
+	}
+
+	backendCount struct {
+		backend
+		Count int     `json:"count"`
+		Share float64 `json:"share"`
+	}
+)
+
+// newReport summarizes the results of a run.
+func newReport(target string, elapsed time.Duration, results []result) *report {
+	r := &report{
+		Target:   target,
+		Duration: elapsed.Seconds(),
+		Requests: len(results),
+		Codes:    map[string]int{},
+	}
+	if elapsed > 0 {
+		r.QPS = float64(len(results)) / elapsed.Seconds()
+	}
+
+	latencies := make([]time.Duration, 0, len(results))
+	backends := map[backend]int{}
+	succeeded := 0
+	for _, res := range results {
+		latencies = append(latencies, res.latency)
+		r.Codes[res.code.String()]++
+		if res.backend != (backend{}) {
+			backends[res.backend]++
+			succeeded++
+		}
+	}
+
+	r.Latency = newLatencyStats(latencies)
+	r.Histogram = newHistogram(latencies)
+
+	for b, count := range backends {
+		r.Backends = append(r.Backends, backendCount{backend: b, Count: count, Share: float64(count) / float64(succeeded)})
+	}
+	sort.Slice(r.Backends, func(i, j int) bool {
+		if r.Backends[i].Count != r.Backends[j].Count {
+			return r.Backends[i].Count > r.Backends[j].Count
+		}
+		return r.Backends[i].key() < r.Backends[j].key()
+	})
+
+	return r
+}
+
+func newLatencyStats(latencies []time.Duration) latencyStats {
+	if len(latencies) == 0 {
+		return latencyStats{}
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+
+	return latencyStats{
+		Min:  ms(sorted[0]),
+		Mean: ms(total / time.Duration(len(sorted))),
+		P50:  ms(percentile(sorted, 50)),
+		P90:  ms(percentile(sorted, 90)),
+		P95:  ms(percentile(sorted, 95)),
+		P99:  ms(percentile(sorted, 99)),
+		P999: ms(percentile(sorted, 99.9)),
+		Max:  ms(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile returns the nearest rank percentile of sorted.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func newHistogram(latencies []time.Duration) []bucket {
+	counts := make([]int, len(_bucketBounds)+1)
+	for _, l := range latencies {
+		i := sort.Search(len(_bucketBounds), func(i int) bool { return l <= _bucketBounds[i] })
+		counts[i]++
+	}
+
+	buckets := make([]bucket, 0, len(counts))
+	for i, count := range counts {
+		b := bucket{Count: count}
+		if i < len(_bucketBounds) {
+			bound := ms(_bucketBounds[i])
+			b.UpperBound = &bound
+		}
+		buckets = append(buckets, b)
+	}
+
+	return buckets
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func (b backend) key() string {
+	return b.Hostname + "/" + b.BeaconName
+}
+
+func (b bucket) label() string {
+	if b.UpperBound == nil {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(*b.UpperBound, 'f', -1, 64)
+}
+
+// write writes the report in the format.
+func (r *report) write(w io.Writer, format string) error {
+	switch format {
+	case FormatText:
+		return r.writeText(w)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case FormatCSV:
+		return r.writeCSV(w)
+	}
+
+	return fmt.Errorf("unknown report format %q, expected text, json or csv", format)
+}
+
+func (r *report) writeText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Target:\t%s\n", r.Target)
+	fmt.Fprintf(tw, "Duration:\t%.2fs\n", r.Duration)
+	fmt.Fprintf(tw, "Requests:\t%d\n", r.Requests)
+	fmt.Fprintf(tw, "QPS:\t%.1f\n", r.QPS)
+
+	l := r.Latency
+	fmt.Fprintf(tw, "\nLatency (ms)\n")
+	fmt.Fprintf(tw, "  min\tmean\tp50\tp90\tp95\tp99\tp99.9\tmax\n")
+	fmt.Fprintf(tw, "  %.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\n", l.Min, l.Mean, l.P50, l.P90, l.P95, l.P99, l.P999, l.Max)
+
+	fmt.Fprintf(tw, "\nHistogram (ms)\n")
+	most := 0
+	for _, b := range r.Histogram {
+		most = max(most, b.Count)
+	}
+	for _, b := range r.Histogram {
+		if b.Count == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "  <= %s\t%d\t%s\n", b.label(), b.Count, strings.Repeat("■", (b.Count*40+most-1)/most))
+	}
+
+	fmt.Fprintf(tw, "\nStatus codes\n")
+	for _, code := range sortedKeys(r.Codes) {
+		fmt.Fprintf(tw, "  %s\t%d\n", code, r.Codes[code])
+	}
+
+	fmt.Fprintf(tw, "\nBackends\n")
+	fmt.Fprintf(tw, "  HOSTNAME\tBEACON\tCOUNT\tSHARE\n")
+	for _, b := range r.Backends {
+		fmt.Fprintf(tw, "  %s\t%s\t%d\t%.1f%%\n", orDash(b.Hostname), orDash(b.BeaconName), b.Count, b.Share*100)
+	}
+
+	return tw.Flush()
+}
+
+// writeCSV writes a section,name,value row per figure, so the report can be
+// loaded into a spreadsheet or filtered by section.
+func (r *report) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+
+	rows := [][]string{
+		{"section", "name", "value"},
+		{"summary", "target", r.Target},
+		{"summary", "duration_seconds", f(r.Duration)},
+		{"summary", "requests", strconv.Itoa(r.Requests)},
+		{"summary", "qps", f(r.QPS)},
+		{"latency_ms", "min", f(r.Latency.Min)},
+		{"latency_ms", "mean", f(r.Latency.Mean)},
+		{"latency_ms", "p50", f(r.Latency.P50)},
+		{"latency_ms", "p90", f(r.Latency.P90)},
+		{"latency_ms", "p95", f(r.Latency.P95)},
+		{"latency_ms", "p99", f(r.Latency.P99)},
+		{"latency_ms", "p99.9", f(r.Latency.P999)},
+		{"latency_ms", "max", f(r.Latency.Max)},
+	}
+	for _, b := range r.Histogram {
+		rows = append(rows, []string{"histogram_ms", "le_" + b.label(), strconv.Itoa(b.Count)})
+	}
+	for _, code := range sortedKeys(r.Codes) {
+		rows = append(rows, []string{"code", code, strconv.Itoa(r.Codes[code])})
+	}
+	for _, b := range r.Backends {
+		rows = append(rows, []string{"backend", b.key(), strconv.Itoa(b.Count)})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("fail to write report: %w", err)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/troydai/grpcbeacon/internal/certgen"
+)
+
+const _certgenUsage = `Usage: server certgen [flags]
+
+Generates a certificate authority, a server certificate and client
+certificates into the output directory:
+
+  ca.crt.pem, ca.key.pem          the certificate authority
+  server.crt.pem, server.key.pem  the server certificate
+  client-<name>.crt.pem, ...      a client certificate per -client flag
+
+Flags:
+`
+
+// errFlagsReported is returned when the flag set already reported an
+// invalid flag.
+var errFlagsReported = errors.New("invalid flags")
+
+// clientFlags collects the repeated -client flags.
+type clientFlags []string
+
+func (c *clientFlags) String() string { return strings.Join(*c, " ") }
+
+func (c *clientFlags) Set(v string) error {
+	*c = append(*c, v)
+	return nil
+}
+
+// runCertgen implements the certgen subcommand.
+func runCertgen(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("certgen", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), _certgenUsage)
+		fs.PrintDefaults()
+	}
+
+	var (
+		out        = fs.String("out", ".", "output directory")
+		keyType    = fs.String("key-type", certgen.KeyTypeEC, "key type, ec or rsa")
+		keySize    = fs.Int("key-size", 0, "EC curve size (256, 384, 521) or RSA modulus size, 256 or 2048 by default")
+		days       = fs.Int("days", int(certgen.DefaultValidity/(24*time.Hour)), "validity in days")
+		caName     = fs.String("ca-name", "grpcbeacon CA", "common name of the generated certificate authority")
+		caCert     = fs.String("ca-cert", "", "issue from this existing CA certificate instead of generating one")
+		caKey      = fs.String("ca-key", "", "private key of -ca-cert")
+		serverName = fs.String("server-name", "localhost", "common name of the server certificate")
+		sans       = fs.String("san", "localhost,127.0.0.1,::1", "comma separated SANs of the server certificate: DNS names, IPs, emails or URIs")
+		clients    clientFlags
+	)
+	fs.Var(&clients, "client", "generate a client certificate NAME[=SAN,...], e.g. frontend=spiffe://example.org/frontend; repeatable")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errFlagsReported
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if (*caCert == "") != (*caKey == "") {
+		return fmt.Errorf("-ca-cert and -ca-key must be set together")
+	}
+	if *days <= 0 {
+		return fmt.Errorf("-days must be positive")
+	}
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return fmt.Errorf("fail to create output directory: %w", err)
+	}
+
+	base := certgen.Options{
+		KeyType:  *keyType,
+		KeySize:  *keySize,
+		Validity: time.Duration(*days) * 24 * time.Hour,
+	}
+
+	var ca *certgen.Certificate
+	var err error
+	if *caCert != "" {
+		if ca, err = certgen.LoadFiles(*caCert, *caKey); err != nil {
+			return err
+		}
+	} else {
+		opts := base
+		opts.CommonName = *caName
+		if ca, err = certgen.NewCA(opts); err != nil {
+			return fmt.Errorf("fail to generate CA: %w", err)
+		}
+		if err := write(stdout, ca, *out, "ca"); err != nil {
+			return err
+		}
+	}
+
+	opts := base
+	opts.CommonName = *serverName
+	opts.SANs = certgen.ParseSANs(*sans)
+	opts.Usage = certgen.UsageServer
+	server, err := ca.Issue(opts)
+	if err != nil {
+		return fmt.Errorf("fail to generate server certificate: %w", err)
+	}
+	if err := write(stdout, server, *out, "server"); err != nil {
+		return err
+	}
+
+	for _, c := range clients {
+		name, clientSANs, _ := strings.Cut(c, "=")
+		if name == "" {
+			return fmt.Errorf("invalid -client %q, expected NAME[=SAN,...]", c)
+		}
+
+		opts := base
+		opts.CommonName = name
+		opts.SANs = certgen.ParseSANs(clientSANs)
+		opts.Usage = certgen.UsageClient
+		client, err := ca.Issue(opts)
+		if err != nil {
+			return fmt.Errorf("fail to generate client certificate %s: %w", name, err)
+		}
+		if err := write(stdout, client, *out, "client-"+name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func write(stdout io.Writer, c *certgen.Certificate, dir, name string) error {
+	if err := c.WriteFiles(dir, name); err != nil {
+		return fmt.Errorf("fail to write %s: %w", name, err)
+	}
+
+	fmt.Fprintf(stdout, "%s: %s, expires %s, sha256 %s\n", name, c.Cert.Subject, c.Cert.NotAfter.Format(time.RFC3339), c.Fingerprint())
+	return nil
+}
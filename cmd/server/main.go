@@ -1,20 +1,42 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
 	"go.uber.org/fx"
 
 	"github.com/troydai/grpcbeacon/internal/beacon"
 	"github.com/troydai/grpcbeacon/internal/health"
 	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/metrics"
 	"github.com/troydai/grpcbeacon/internal/rpc"
 	"github.com/troydai/grpcbeacon/internal/settings"
+	"github.com/troydai/grpcbeacon/internal/tracing"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "certgen" {
+		err := runCertgen(os.Args[2:], os.Stdout)
+		switch {
+		case err == nil, errors.Is(err, flag.ErrHelp):
+		case errors.Is(err, errFlagsReported):
+			os.Exit(2)
+		default:
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	basic := fx.Options(
 		settings.Module,
 		rpc.Module,
 		logging.Module,
+		metrics.Module,
+		tracing.Module,
 	)
 
 	services := fx.Options(
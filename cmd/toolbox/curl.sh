@@ -1,3 +1,7 @@
 #!/bin/sh
 
-grpcurl -plaintext -proto etc/protos/beacon.proto localhost:7000 grpcbeacon.Beacon/Signal
+# Calls the beacon through grpcurl. The address defaults to the local server,
+# set BEACON_ADDR to reach another one. beaconctl, installed next to this
+# script, is the richer alternative.
+grpcurl -plaintext -import-path /root -proto api.proto -d '{"message": "curl"}' \
+	"${BEACON_ADDR:-localhost:8080}" troydai.grpcbeacon.v1.BeaconService/Signal
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+func TestIntegration_FaultInjection(t *testing.T) {
+	testConfig := settings.Configuration{
+		Name:    "fault-beacon",
+		Address: "127.0.0.1",
+	}
+
+	var server *rpc.Server
+	app := fxtest.New(t,
+		fx.Populate(&server),
+		fx.Provide(func() settings.Configuration { return testConfig }),
+		fx.Provide(func() settings.Environment { return settings.Environment{HostName: "fault-host"} }),
+		logging.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+
+	startCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Start(startCtx))
+
+	port := server.Addrs()[0].(*net.TCPAddr).Port
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("127.0.0.1:%d", port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+
+	client := pb.NewBeaconServiceClient(conn)
+
+	t.Run("status code from request carries error details", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := client.Signal(ctx, &pb.SignalRequest{
+			Fault: &pb.Fault{
+				Code:       uint32(codes.Unavailable),
+				Message:    "try again later",
+				RetryDelay: durationpb.New(250 * time.Millisecond),
+			},
+		})
+		require.Error(t, err)
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.Unavailable, st.Code())
+		assert.Equal(t, "try again later", st.Message())
+
+		var errorInfo *errdetails.ErrorInfo
+		var retryInfo *errdetails.RetryInfo
+		for _, d := range st.Details() {
+			switch d := d.(type) {
+			case *errdetails.ErrorInfo:
+				errorInfo = d
+			case *errdetails.RetryInfo:
+				retryInfo = d
+			}
+		}
+		require.NotNil(t, errorInfo)
+		assert.Equal(t, "INJECTED_FAULT", errorInfo.Reason)
+		require.NotNil(t, retryInfo)
+		assert.Equal(t, 250*time.Millisecond, retryInfo.RetryDelay.AsDuration())
+	})
+
+	t.Run("status code from metadata", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		ctx = metadata.AppendToOutgoingContext(ctx, beacon.MetadataStatusCode, "resource_exhausted")
+
+		_, err := client.Signal(ctx, &pb.SignalRequest{})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("failure probability from metadata overrides request", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		ctx = metadata.AppendToOutgoingContext(ctx, beacon.MetadataFailureProbability, "0")
+
+		_, err := client.Signal(ctx, &pb.SignalRequest{
+			Fault: &pb.Fault{Code: uint32(codes.Internal)},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("explicit zero failure probability never fails", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		for range 10 {
+			_, err := client.Signal(ctx, &pb.SignalRequest{
+				Fault: &pb.Fault{Code: uint32(codes.Internal), FailureProbability: proto.Float64(0)},
+			})
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		start := time.Now()
+		resp, err := client.Signal(ctx, &pb.SignalRequest{
+			Fault: &pb.Fault{Delay: durationpb.New(200 * time.Millisecond)},
+		})
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+		// The deadline is the one remaining when the call was received,
+		// before the delay.
+		assert.Greater(t, resp.GetRequest().GetDeadline().AsDuration(), 5*time.Second-100*time.Millisecond)
+	})
+
+	t.Run("hang until deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		ctx = metadata.AppendToOutgoingContext(ctx, beacon.MetadataHang, "true")
+
+		_, err := client.Signal(ctx, &pb.SignalRequest{})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("invalid fault is rejected", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := client.Signal(ctx, &pb.SignalRequest{
+			Fault: &pb.Fault{Code: uint32(codes.Internal), FailureProbability: proto.Float64(1.5)},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Stop(stopCtx))
+}
@@ -0,0 +1,455 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: troydai/grpcbeacon/v1/admin.proto
+
+package grpcbeaconv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ServingStatus int32
+
+const (
+	ServingStatus_SERVING_STATUS_UNSPECIFIED     ServingStatus = 0
+	ServingStatus_SERVING_STATUS_SERVING         ServingStatus = 1
+	ServingStatus_SERVING_STATUS_NOT_SERVING     ServingStatus = 2
+	ServingStatus_SERVING_STATUS_SERVICE_UNKNOWN ServingStatus = 3
+)
+
+// Enum value maps for ServingStatus.
+var (
+	ServingStatus_name = map[int32]string{
+		0: "SERVING_STATUS_UNSPECIFIED",
+		1: "SERVING_STATUS_SERVING",
+		2: "SERVING_STATUS_NOT_SERVING",
+		3: "SERVING_STATUS_SERVICE_UNKNOWN",
+	}
+	ServingStatus_value = map[string]int32{
+		"SERVING_STATUS_UNSPECIFIED":     0,
+		"SERVING_STATUS_SERVING":         1,
+		"SERVING_STATUS_NOT_SERVING":     2,
+		"SERVING_STATUS_SERVICE_UNKNOWN": 3,
+	}
+)
+
+func (x ServingStatus) Enum() *ServingStatus {
+	p := new(ServingStatus)
+	*p = x
+	return p
+}
+
+func (x ServingStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServingStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_troydai_grpcbeacon_v1_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (ServingStatus) Type() protoreflect.EnumType {
+	return &file_troydai_grpcbeacon_v1_admin_proto_enumTypes[0]
+}
+
+func (x ServingStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServingStatus.Descriptor instead.
+func (ServingStatus) EnumDescriptor() ([]byte, []int) {
+	return file_troydai_grpcbeacon_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type SetServingStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// service is the name reported through grpc.health.v1.Health, e.g.
+	// "troydai.grpcbeacon.v1.BeaconService" or "" for the overall status.
+	Service string        `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Status  ServingStatus `protobuf:"varint,2,opt,name=status,proto3,enum=troydai.grpcbeacon.v1.ServingStatus" json:"status,omitempty"`
+}
+
+func (x *SetServingStatusRequest) Reset() {
+	*x = SetServingStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_troydai_grpcbeacon_v1_admin_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetServingStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetServingStatusRequest) ProtoMessage() {}
+
+func (x *SetServingStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_troydai_grpcbeacon_v1_admin_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetServingStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetServingStatusRequest) Descriptor() ([]byte, []int) {
+	return file_troydai_grpcbeacon_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetServingStatusRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *SetServingStatusRequest) GetStatus() ServingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ServingStatus_SERVING_STATUS_UNSPECIFIED
+}
+
+type SetServingStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// previous_status is SERVING_STATUS_UNSPECIFIED when the service was not
+	// known before.
+	PreviousStatus ServingStatus `protobuf:"varint,1,opt,name=previous_status,json=previousStatus,proto3,enum=troydai.grpcbeacon.v1.ServingStatus" json:"previous_status,omitempty"`
+}
+
+func (x *SetServingStatusResponse) Reset() {
+	*x = SetServingStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_troydai_grpcbeacon_v1_admin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetServingStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetServingStatusResponse) ProtoMessage() {}
+
+func (x *SetServingStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_troydai_grpcbeacon_v1_admin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetServingStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetServingStatusResponse) Descriptor() ([]byte, []int) {
+	return file_troydai_grpcbeacon_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetServingStatusResponse) GetPreviousStatus() ServingStatus {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ServingStatus_SERVING_STATUS_UNSPECIFIED
+}
+
+type ListServingStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListServingStatusRequest) Reset() {
+	*x = ListServingStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_troydai_grpcbeacon_v1_admin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListServingStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServingStatusRequest) ProtoMessage() {}
+
+func (x *ListServingStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_troydai_grpcbeacon_v1_admin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServingStatusRequest.ProtoReflect.Descriptor instead.
+func (*ListServingStatusRequest) Descriptor() ([]byte, []int) {
+	return file_troydai_grpcbeacon_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+type ListServingStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Statuses map[string]ServingStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=troydai.grpcbeacon.v1.ServingStatus"`
+}
+
+func (x *ListServingStatusResponse) Reset() {
+	*x = ListServingStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_troydai_grpcbeacon_v1_admin_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListServingStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServingStatusResponse) ProtoMessage() {}
+
+func (x *ListServingStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_troydai_grpcbeacon_v1_admin_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServingStatusResponse.ProtoReflect.Descriptor instead.
+func (*ListServingStatusResponse) Descriptor() ([]byte, []int) {
+	return file_troydai_grpcbeacon_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListServingStatusResponse) GetStatuses() map[string]ServingStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+var File_troydai_grpcbeacon_v1_admin_proto protoreflect.FileDescriptor
+
+var file_troydai_grpcbeacon_v1_admin_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x74, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x15, 0x74, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x22, 0x71, 0x0a, 0x17, 0x53, 0x65,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x3c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x24, 0x2e, 0x74, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x62, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x69, 0x0a,
+	0x18, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0f, 0x70, 0x72, 0x65,
+	0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x24, 0x2e, 0x74, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x1a, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0xda, 0x01, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x5a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x74, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x1a, 0x61,
+	0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x24, 0x2e, 0x74, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x62,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x2a, 0x8f, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x45, 0x52, 0x56, 0x49, 0x4e, 0x47, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x52, 0x56, 0x49, 0x4e, 0x47, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
+	0x1e, 0x0a, 0x1a, 0x53, 0x45, 0x52, 0x56, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12,
+	0x22, 0x0a, 0x1e, 0x53, 0x45, 0x52, 0x56, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x03, 0x32, 0xff, 0x01, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x75, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x2e, 0x74, 0x72, 0x6f, 0x79, 0x64,
+	0x61, 0x69, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x74, 0x72, 0x6f, 0x79, 0x64,
+	0x61, 0x69, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x78, 0x0a, 0x11, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x2f, 0x2e, 0x74, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x62,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x30, 0x2e, 0x74, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0xc8, 0x01, 0x0a, 0x19, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x72,
+	0x6f, 0x79, 0x64, 0x61, 0x69, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e,
+	0x2e, 0x76, 0x31, 0x42, 0x0a, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50,
+	0x01, 0x5a, 0x29, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x74, 0x72, 0x6f, 0x79, 0x64, 0x61,
+	0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2f, 0x76, 0x31, 0x3b,
+	0x67, 0x72, 0x70, 0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x54,
+	0x47, 0x58, 0xaa, 0x02, 0x15, 0x54, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69, 0x2e, 0x47, 0x72, 0x70,
+	0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x15, 0x54, 0x72, 0x6f,
+	0x79, 0x64, 0x61, 0x69, 0x5c, 0x47, 0x72, 0x70, 0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x5c,
+	0x56, 0x31, 0xe2, 0x02, 0x21, 0x54, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69, 0x5c, 0x47, 0x72, 0x70,
+	0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x17, 0x54, 0x72, 0x6f, 0x79, 0x64, 0x61, 0x69,
+	0x3a, 0x3a, 0x47, 0x72, 0x70, 0x63, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x3a, 0x3a, 0x56, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_troydai_grpcbeacon_v1_admin_proto_rawDescOnce sync.Once
+	file_troydai_grpcbeacon_v1_admin_proto_rawDescData = file_troydai_grpcbeacon_v1_admin_proto_rawDesc
+)
+
+func file_troydai_grpcbeacon_v1_admin_proto_rawDescGZIP() []byte {
+	file_troydai_grpcbeacon_v1_admin_proto_rawDescOnce.Do(func() {
+		file_troydai_grpcbeacon_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_troydai_grpcbeacon_v1_admin_proto_rawDescData)
+	})
+	return file_troydai_grpcbeacon_v1_admin_proto_rawDescData
+}
+
+var file_troydai_grpcbeacon_v1_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_troydai_grpcbeacon_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_troydai_grpcbeacon_v1_admin_proto_goTypes = []interface{}{
+	(ServingStatus)(0),                // 0: troydai.grpcbeacon.v1.ServingStatus
+	(*SetServingStatusRequest)(nil),   // 1: troydai.grpcbeacon.v1.SetServingStatusRequest
+	(*SetServingStatusResponse)(nil),  // 2: troydai.grpcbeacon.v1.SetServingStatusResponse
+	(*ListServingStatusRequest)(nil),  // 3: troydai.grpcbeacon.v1.ListServingStatusRequest
+	(*ListServingStatusResponse)(nil), // 4: troydai.grpcbeacon.v1.ListServingStatusResponse
+	nil,                               // 5: troydai.grpcbeacon.v1.ListServingStatusResponse.StatusesEntry
+}
+var file_troydai_grpcbeacon_v1_admin_proto_depIdxs = []int32{
+	0, // 0: troydai.grpcbeacon.v1.SetServingStatusRequest.status:type_name -> troydai.grpcbeacon.v1.ServingStatus
+	0, // 1: troydai.grpcbeacon.v1.SetServingStatusResponse.previous_status:type_name -> troydai.grpcbeacon.v1.ServingStatus
+	5, // 2: troydai.grpcbeacon.v1.ListServingStatusResponse.statuses:type_name -> troydai.grpcbeacon.v1.ListServingStatusResponse.StatusesEntry
+	0, // 3: troydai.grpcbeacon.v1.ListServingStatusResponse.StatusesEntry.value:type_name -> troydai.grpcbeacon.v1.ServingStatus
+	1, // 4: troydai.grpcbeacon.v1.AdminService.SetServingStatus:input_type -> troydai.grpcbeacon.v1.SetServingStatusRequest
+	3, // 5: troydai.grpcbeacon.v1.AdminService.ListServingStatus:input_type -> troydai.grpcbeacon.v1.ListServingStatusRequest
+	2, // 6: troydai.grpcbeacon.v1.AdminService.SetServingStatus:output_type -> troydai.grpcbeacon.v1.SetServingStatusResponse
+	4, // 7: troydai.grpcbeacon.v1.AdminService.ListServingStatus:output_type -> troydai.grpcbeacon.v1.ListServingStatusResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_troydai_grpcbeacon_v1_admin_proto_init() }
+func file_troydai_grpcbeacon_v1_admin_proto_init() {
+	if File_troydai_grpcbeacon_v1_admin_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_troydai_grpcbeacon_v1_admin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetServingStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_troydai_grpcbeacon_v1_admin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetServingStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_troydai_grpcbeacon_v1_admin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListServingStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_troydai_grpcbeacon_v1_admin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListServingStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_troydai_grpcbeacon_v1_admin_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_troydai_grpcbeacon_v1_admin_proto_goTypes,
+		DependencyIndexes: file_troydai_grpcbeacon_v1_admin_proto_depIdxs,
+		EnumInfos:         file_troydai_grpcbeacon_v1_admin_proto_enumTypes,
+		MessageInfos:      file_troydai_grpcbeacon_v1_admin_proto_msgTypes,
+	}.Build()
+	File_troydai_grpcbeacon_v1_admin_proto = out.File
+	file_troydai_grpcbeacon_v1_admin_proto_rawDesc = nil
+	file_troydai_grpcbeacon_v1_admin_proto_goTypes = nil
+	file_troydai_grpcbeacon_v1_admin_proto_depIdxs = nil
+}
@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BeaconService_Signal_FullMethodName = "/troydai.grpcbeacon.v1.BeaconService/Signal"
+	BeaconService_Signal_FullMethodName       = "/troydai.grpcbeacon.v1.BeaconService/Signal"
+	BeaconService_SignalStream_FullMethodName = "/troydai.grpcbeacon.v1.BeaconService/SignalStream"
+	BeaconService_Ping_FullMethodName         = "/troydai.grpcbeacon.v1.BeaconService/Ping"
 )
 
 // BeaconServiceClient is the client API for BeaconService service.
@@ -27,6 +29,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BeaconServiceClient interface {
 	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error)
+	SignalStream(ctx context.Context, in *SignalStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SignalResponse], error)
+	Ping(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PingRequest, PingResponse], error)
 }
 
 type beaconServiceClient struct {
@@ -47,11 +51,45 @@ func (c *beaconServiceClient) Signal(ctx context.Context, in *SignalRequest, opt
 	return out, nil
 }
 
+func (c *beaconServiceClient) SignalStream(ctx context.Context, in *SignalStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SignalResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BeaconService_ServiceDesc.Streams[0], BeaconService_SignalStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SignalStreamRequest, SignalResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BeaconService_SignalStreamClient = grpc.ServerStreamingClient[SignalResponse]
+
+func (c *beaconServiceClient) Ping(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PingRequest, PingResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BeaconService_ServiceDesc.Streams[1], BeaconService_Ping_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PingRequest, PingResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BeaconService_PingClient = grpc.BidiStreamingClient[PingRequest, PingResponse]
+
 // BeaconServiceServer is the server API for BeaconService service.
 // All implementations must embed UnimplementedBeaconServiceServer
 // for forward compatibility.
 type BeaconServiceServer interface {
 	Signal(context.Context, *SignalRequest) (*SignalResponse, error)
+	SignalStream(*SignalStreamRequest, grpc.ServerStreamingServer[SignalResponse]) error
+	Ping(grpc.BidiStreamingServer[PingRequest, PingResponse]) error
 	mustEmbedUnimplementedBeaconServiceServer()
 }
 
@@ -65,6 +103,12 @@ type UnimplementedBeaconServiceServer struct{}
 func (UnimplementedBeaconServiceServer) Signal(context.Context, *SignalRequest) (*SignalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Signal not implemented")
 }
+func (UnimplementedBeaconServiceServer) SignalStream(*SignalStreamRequest, grpc.ServerStreamingServer[SignalResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SignalStream not implemented")
+}
+func (UnimplementedBeaconServiceServer) Ping(grpc.BidiStreamingServer[PingRequest, PingResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
 func (UnimplementedBeaconServiceServer) mustEmbedUnimplementedBeaconServiceServer() {}
 func (UnimplementedBeaconServiceServer) testEmbeddedByValue()                       {}
 
@@ -104,6 +148,24 @@ func _BeaconService_Signal_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BeaconService_SignalStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignalStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BeaconServiceServer).SignalStream(m, &grpc.GenericServerStream[SignalStreamRequest, SignalResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BeaconService_SignalStreamServer = grpc.ServerStreamingServer[SignalResponse]
+
+func _BeaconService_Ping_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BeaconServiceServer).Ping(&grpc.GenericServerStream[PingRequest, PingResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BeaconService_PingServer = grpc.BidiStreamingServer[PingRequest, PingResponse]
+
 // BeaconService_ServiceDesc is the grpc.ServiceDesc for BeaconService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -116,6 +178,18 @@ var BeaconService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _BeaconService_Signal_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SignalStream",
+			Handler:       _BeaconService_SignalStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Ping",
+			Handler:       _BeaconService_Ping_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "troydai/grpcbeacon/v1/api.proto",
 }
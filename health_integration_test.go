@@ -12,9 +12,13 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxtest"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
-	healthpb "github.com/troydai/grpcbeacon/gen/go/grpc/health/v1"
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
 	"github.com/troydai/grpcbeacon/internal/beacon"
 	"github.com/troydai/grpcbeacon/internal/health"
 	"github.com/troydai/grpcbeacon/internal/logging"
@@ -23,25 +27,23 @@ import (
 )
 
 func TestIntegration_HealthCheck(t *testing.T) {
-	// Find an available port
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
-	require.NoError(t, err)
-	port := listener.Addr().(*net.TCPAddr).Port
-	require.NoError(t, listener.Close())
-
 	// Override configuration for testing
 	testConfig := settings.Configuration{
 		Name:    "health-test-beacon",
 		Address: "127.0.0.1",
-		Port:    port,
 	}
 
 	testEnv := settings.Environment{
 		HostName: "health-test-host",
 	}
 
+	var registry *health.Registry
+
 	// Create the fx app with test configuration
+	var server *rpc.Server
 	app := fxtest.New(t,
+		fx.Populate(&server),
+		fx.Populate(&registry),
 		fx.Provide(func() settings.Configuration { return testConfig }),
 		fx.Provide(func() settings.Environment { return testEnv }),
 		logging.Module,
@@ -56,8 +58,7 @@ func TestIntegration_HealthCheck(t *testing.T) {
 
 	require.NoError(t, app.Start(startCtx))
 
-	// Wait a bit for the server to be ready
-	time.Sleep(100 * time.Millisecond)
+	port := server.Addrs()[0].(*net.TCPAddr).Port
 
 	// Test the health check service
 	t.Run("Health check endpoint works", func(t *testing.T) {
@@ -183,8 +184,130 @@ func TestIntegration_HealthCheck(t *testing.T) {
 		assert.Contains(t, err.Error(), "service unknown-service not found")
 	})
 
+	t.Run("Health watch streams status changes", func(t *testing.T) {
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("127.0.0.1:%d", port),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, conn.Close()) }()
+
+		client := healthpb.NewHealthClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		watchA, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "watched"})
+		require.NoError(t, err)
+		watchB, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "watched"})
+		require.NoError(t, err)
+
+		for _, w := range []healthpb.Health_WatchClient{watchA, watchB} {
+			resp, err := w.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, resp.Status)
+		}
+
+		registry.SetServingStatus("watched", healthpb.HealthCheckResponse_SERVING)
+		for _, w := range []healthpb.Health_WatchClient{watchA, watchB} {
+			resp, err := w.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+		}
+
+		registry.SetServingStatus("watched", healthpb.HealthCheckResponse_NOT_SERVING)
+		for _, w := range []healthpb.Health_WatchClient{watchA, watchB} {
+			resp, err := w.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+		}
+	})
+
 	// Clean shutdown
 	stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	require.NoError(t, app.Stop(stopCtx))
 }
+
+func TestIntegration_HealthAdmin(t *testing.T) {
+	testConfig := settings.Configuration{
+		Name:    "admin-test-beacon",
+		Address: "127.0.0.1",
+		Admin:   &settings.Admin{Enabled: true, Token: "secret-token"},
+	}
+
+	var server *rpc.Server
+	app := fxtest.New(t,
+		fx.Populate(&server),
+		fx.Provide(func() settings.Configuration { return testConfig }),
+		fx.Provide(func() settings.Environment { return settings.Environment{HostName: "admin-test-host"} }),
+		logging.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+
+	startCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Start(startCtx))
+
+	port := server.Addrs()[0].(*net.TCPAddr).Port
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("127.0.0.1:%d", port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+
+	healthClient := healthpb.NewHealthClient(conn)
+	adminClient := pb.NewAdminServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	authorized := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret-token")
+
+	t.Run("registered services are serving", func(t *testing.T) {
+		for _, service := range []string{pb.BeaconService_ServiceDesc.ServiceName, pb.AdminService_ServiceDesc.ServiceName} {
+			resp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+			require.NoError(t, err)
+			assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status, service)
+		}
+	})
+
+	t.Run("admin requires the token", func(t *testing.T) {
+		_, err := adminClient.ListServingStatus(ctx, &pb.ListServingStatusRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		wrong := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer wrong-token")
+		_, err = adminClient.ListServingStatus(wrong, &pb.ListServingStatusRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("admin flips the serving status", func(t *testing.T) {
+		service := pb.BeaconService_ServiceDesc.ServiceName
+
+		resp, err := adminClient.SetServingStatus(authorized, &pb.SetServingStatusRequest{
+			Service: service,
+			Status:  pb.ServingStatus_SERVING_STATUS_NOT_SERVING,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, pb.ServingStatus_SERVING_STATUS_SERVING, resp.PreviousStatus)
+
+		check, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check.Status)
+
+		list, err := adminClient.ListServingStatus(authorized, &pb.ListServingStatusRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, pb.ServingStatus_SERVING_STATUS_NOT_SERVING, list.Statuses[service])
+		assert.Equal(t, pb.ServingStatus_SERVING_STATUS_SERVING, list.Statuses[""])
+
+		_, err = adminClient.SetServingStatus(authorized, &pb.SetServingStatusRequest{Service: service})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Stop(stopCtx))
+}
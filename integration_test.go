@@ -13,7 +13,11 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxtest"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
 	"github.com/troydai/grpcbeacon/internal/beacon"
@@ -24,17 +28,10 @@ import (
 )
 
 func TestIntegration_ServerStartsAndEchoWorks(t *testing.T) {
-	// Find an available port
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
-	require.NoError(t, err)
-	port := listener.Addr().(*net.TCPAddr).Port
-	require.NoError(t, listener.Close())
-
 	// Override configuration for testing
 	testConfig := settings.Configuration{
 		Name:    "test-beacon",
 		Address: "127.0.0.1",
-		Port:    port,
 	}
 
 	testEnv := settings.Environment{
@@ -42,7 +39,9 @@ func TestIntegration_ServerStartsAndEchoWorks(t *testing.T) {
 	}
 
 	// Create the fx app with test configuration
+	var server *rpc.Server
 	app := fxtest.New(t,
+		fx.Populate(&server),
 		// Override the settings providers with test values
 		fx.Provide(func() settings.Configuration { return testConfig }),
 		fx.Provide(func() settings.Environment { return testEnv }),
@@ -60,8 +59,7 @@ func TestIntegration_ServerStartsAndEchoWorks(t *testing.T) {
 
 	require.NoError(t, app.Start(startCtx))
 
-	// Wait a bit for the server to be ready
-	time.Sleep(100 * time.Millisecond)
+	port := server.Addrs()[0].(*net.TCPAddr).Port
 
 	// Test the gRPC service
 	t.Run("Signal endpoint works", func(t *testing.T) {
@@ -98,6 +96,81 @@ func TestIntegration_ServerStartsAndEchoWorks(t *testing.T) {
 		assert.Equal(t, "test-beacon", resp.Details["BeaconName"])
 	})
 
+	// Test the payload options of the Signal method
+	t.Run("Signal payload", func(t *testing.T) {
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("127.0.0.1:%d", port),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, conn.Close()) }()
+
+		client := pb.NewBeaconServiceClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := client.Signal(ctx, &pb.SignalRequest{
+			Payload: &pb.PayloadSpec{Size: 1024},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, make([]byte, 1024), resp.Payload)
+
+		resp, err = client.Signal(ctx, &pb.SignalRequest{
+			Payload: &pb.PayloadSpec{Size: 10, Type: pb.PayloadType_PAYLOAD_TYPE_TEXT, Text: "abc"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "abcabcabca", string(resp.Payload))
+
+		resp, err = client.Signal(ctx, &pb.SignalRequest{
+			Payload: &pb.PayloadSpec{Size: 4096, Type: pb.PayloadType_PAYLOAD_TYPE_RANDOM},
+		})
+		require.NoError(t, err)
+		assert.Len(t, resp.Payload, 4096)
+		assert.NotEqual(t, make([]byte, 4096), resp.Payload)
+
+		_, err = client.Signal(ctx, &pb.SignalRequest{
+			Payload: &pb.PayloadSpec{Size: 1 << 40},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	// Test the request information echoed by the Signal method
+	t.Run("Signal echoes request info", func(t *testing.T) {
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("127.0.0.1:%d", port),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, conn.Close()) }()
+
+		client := pb.NewBeaconServiceClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-test-header", "one", "x-test-header", "two", "authorization", "Bearer secret")
+
+		resp, err := client.Signal(ctx, &pb.SignalRequest{}, grpc.UseCompressor(gzip.Name))
+		require.NoError(t, err)
+		require.NotNil(t, resp.Request)
+
+		require.Contains(t, resp.Request.Headers, "x-test-header")
+		assert.Equal(t, []string{"one", "two"}, resp.Request.Headers["x-test-header"].Values)
+		assert.NotContains(t, resp.Request.Headers, "authorization", "credentials are redacted")
+
+		require.NotNil(t, resp.Request.Peer)
+		assert.Contains(t, resp.Request.Peer.Address, "127.0.0.1:")
+		assert.Equal(t, fmt.Sprintf("127.0.0.1:%d", port), resp.Request.Peer.LocalAddress)
+		assert.Nil(t, resp.Request.Peer.Tls)
+
+		require.NotNil(t, resp.Request.Deadline)
+		assert.Greater(t, resp.Request.Deadline.AsDuration(), time.Duration(0))
+		assert.LessOrEqual(t, resp.Request.Deadline.AsDuration(), 5*time.Second)
+
+		assert.Equal(t, gzip.Name, resp.Request.Compressor)
+		assert.Contains(t, resp.Request.AcceptedCompressors, gzip.Name)
+	})
+
 	// Test multiple concurrent calls
 	t.Run("Concurrent calls work", func(t *testing.T) {
 		conn, err := grpc.NewClient(
@@ -150,22 +223,18 @@ func TestIntegration_ServerStartsAndEchoWorks(t *testing.T) {
 
 func TestIntegration_ServerConfiguration(t *testing.T) {
 	// Test that the server respects configuration changes
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
-	require.NoError(t, err)
-	port := listener.Addr().(*net.TCPAddr).Port
-	require.NoError(t, listener.Close())
-
 	testConfig := settings.Configuration{
 		Name:    "custom-beacon-name",
 		Address: "127.0.0.1",
-		Port:    port,
 	}
 
 	testEnv := settings.Environment{
 		HostName: "custom-hostname",
 	}
 
+	var server *rpc.Server
 	app := fxtest.New(t,
+		fx.Populate(&server),
 		fx.Provide(func() settings.Configuration { return testConfig }),
 		fx.Provide(func() settings.Environment { return testEnv }),
 		logging.Module,
@@ -178,7 +247,7 @@ func TestIntegration_ServerConfiguration(t *testing.T) {
 	defer cancel()
 	require.NoError(t, app.Start(startCtx))
 
-	time.Sleep(100 * time.Millisecond)
+	port := server.Addrs()[0].(*net.TCPAddr).Port
 
 	// Test that configuration is reflected in the response
 	conn, err := grpc.NewClient(
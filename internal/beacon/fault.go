@@ -0,0 +1,213 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+)
+
+const (
+	_faultDomain = "grpcbeacon.troydai.cc"
+	_faultReason = "INJECTED_FAULT"
+
+	MetadataDelay              = "x-beacon-delay"
+	MetadataDelayJitter        = "x-beacon-delay-jitter"
+	MetadataStatusCode         = "x-beacon-status-code"
+	MetadataFailureProbability = "x-beacon-failure-probability"
+	MetadataErrorMessage       = "x-beacon-error-message"
+	MetadataRetryDelay         = "x-beacon-retry-delay"
+	MetadataHang               = "x-beacon-hang"
+)
+
+// fault is the resolved form of a pb.Fault merged with the x-beacon-* metadata.
+type fault struct {
+	delay       time.Duration
+	delayJitter time.Duration
+	code        codes.Code
+	probability float64
+	message     string
+	retryDelay  time.Duration
+	hang        bool
+}
+
+// resolveFault returns the fault to inject. The probability is one unless
+// it is set, so that an explicit zero never fails.
+func resolveFault(ctx context.Context, spec *pb.Fault) (fault, error) {
+	f := fault{probability: 1}
+	if spec != nil {
+		var err error
+		if f.delay, err = durationField("delay", spec.Delay); err != nil {
+			return f, err
+		}
+		if f.delayJitter, err = durationField("delay_jitter", spec.DelayJitter); err != nil {
+			return f, err
+		}
+		if f.retryDelay, err = durationField("retry_delay", spec.RetryDelay); err != nil {
+			return f, err
+		}
+		f.code = codes.Code(spec.Code)
+		if spec.FailureProbability != nil {
+			f.probability = *spec.FailureProbability
+		}
+		f.message = spec.Message
+		f.hang = spec.HangUntilDeadline
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	overrides := []struct {
+		key   string
+		apply func(string) error
+	}{
+		{MetadataDelay, func(v string) (err error) { f.delay, err = parseDuration(v); return }},
+		{MetadataDelayJitter, func(v string) (err error) { f.delayJitter, err = parseDuration(v); return }},
+		{MetadataRetryDelay, func(v string) (err error) { f.retryDelay, err = parseDuration(v); return }},
+		{MetadataStatusCode, func(v string) (err error) { f.code, err = parseCode(v); return }},
+		{MetadataFailureProbability, func(v string) (err error) { f.probability, err = strconv.ParseFloat(v, 64); return }},
+		{MetadataHang, func(v string) (err error) { f.hang, err = strconv.ParseBool(v); return }},
+		{MetadataErrorMessage, func(v string) error { f.message = v; return nil }},
+	}
+	for _, o := range overrides {
+		values := md.Get(o.key)
+		if len(values) == 0 {
+			continue
+		}
+		if err := o.apply(values[0]); err != nil {
+			return f, status.Errorf(codes.InvalidArgument, "invalid %s metadata: %v", o.key, err)
+		}
+	}
+
+	if f.probability < 0 || f.probability > 1 {
+		return f, status.Errorf(codes.InvalidArgument, "failure probability must be within [0, 1]: %v", f.probability)
+	}
+	if f.code > codes.Unauthenticated {
+		return f, status.Errorf(codes.InvalidArgument, "unknown status code: %d", f.code)
+	}
+
+	return f, nil
+}
+
+// inject waits for the configured delay or hang and then returns the
+// configured error, if any. A nil return means the call proceeds normally.
+func (f fault) inject(ctx context.Context, logger *zap.Logger) error {
+	if f.hang {
+		logger.Info("injecting hang until deadline")
+		trace.SpanFromContext(ctx).AddEvent("beacon.fault.hang")
+		<-ctx.Done()
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	delay := f.delay
+	if f.delayJitter > 0 {
+		delay += rand.N(f.delayJitter)
+	}
+	if delay > 0 {
+		logger.Info("injecting delay", zap.Duration("delay", delay))
+		trace.SpanFromContext(ctx).AddEvent("beacon.fault.delay", trace.WithAttributes(
+			attribute.String("delay", delay.String()),
+		))
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-timer.C:
+		}
+	}
+
+	if f.code == codes.OK {
+		return nil
+	}
+
+	if rand.Float64() >= f.probability {
+		return nil
+	}
+
+	logger.Info("injecting error", zap.Stringer("code", f.code))
+	trace.SpanFromContext(ctx).AddEvent("beacon.fault.error", trace.WithAttributes(
+		attribute.String("code", f.code.String()),
+	))
+	return f.status().Err()
+}
+
+func (f fault) status() *status.Status {
+	msg := f.message
+	if msg == "" {
+		msg = fmt.Sprintf("beacon injected %s", f.code)
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason: _faultReason,
+		Domain: _faultDomain,
+		Metadata: map[string]string{
+			"code":               f.code.String(),
+			"failureProbability": strconv.FormatFloat(f.probability, 'g', -1, 64),
+		},
+	}}
+	if f.retryDelay > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(f.retryDelay)})
+	}
+
+	st := status.New(f.code, msg)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+
+	return st
+}
+
+func durationField(name string, d *durationpb.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+	if err := d.CheckValid(); err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s: %v", name, err)
+	}
+	if d.AsDuration() < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must not be negative", name)
+	}
+
+	return d.AsDuration(), nil
+}
+
+func parseDuration(v string) (time.Duration, error) {
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative: %s", v)
+	}
+
+	return d, nil
+}
+
+// parseCode accepts both the numeric value and the canonical name of a status
+// code, e.g. "14" and "UNAVAILABLE".
+func parseCode(v string) (codes.Code, error) {
+	if n, err := strconv.ParseUint(v, 10, 32); err == nil {
+		return codes.Code(n), nil
+	}
+
+	var c codes.Code
+	if err := c.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(v)))); err != nil {
+		return 0, err
+	}
+
+	return c, nil
+}
@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc"
 
 	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/logging"
 	"github.com/troydai/grpcbeacon/internal/rpc"
 	"github.com/troydai/grpcbeacon/internal/settings"
 )
@@ -16,9 +17,11 @@ type (
 	Param struct {
 		fx.In
 
-		Env    settings.Environment
-		Config settings.Configuration
-		Logger *zap.Logger
+		Env      settings.Environment
+		Config   settings.Configuration
+		Logger   *zap.Logger
+		Redactor *logging.Redactor
+		Stopping *rpc.Stopping `optional:"true"`
 	}
 
 	Result struct {
@@ -32,10 +35,10 @@ func ProvideRegister(param Param) Result {
 	hostName := param.Env.HostName
 	beaconName := param.Config.Name
 
-	svc := newService(hostName, beaconName, param.Logger)
+	svc := newService(hostName, beaconName, param.Logger, param.Redactor, param.Stopping)
 
 	return Result{
-		Register: rpc.GRPCRegisterFromFn(func(s *grpc.Server) error {
+		Register: rpc.GRPCRegisterFromFn(func(s grpc.ServiceRegistrar) error {
 			pb.RegisterBeaconServiceServer(s, svc)
 			return nil
 		}),
@@ -0,0 +1,45 @@
+package beacon
+
+import (
+	"bytes"
+	"crypto/rand"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+)
+
+const (
+	_maxPayloadSize     = 64 << 20
+	_defaultPayloadText = "grpcbeacon "
+)
+
+func buildPayload(spec *pb.PayloadSpec) ([]byte, error) {
+	if spec == nil || spec.Size == 0 {
+		return nil, nil
+	}
+	if spec.Size > _maxPayloadSize {
+		return nil, status.Errorf(codes.InvalidArgument, "payload size %d exceeds the limit of %d bytes", spec.Size, _maxPayloadSize)
+	}
+
+	size := int(spec.Size)
+	switch spec.Type {
+	case pb.PayloadType_PAYLOAD_TYPE_UNSPECIFIED, pb.PayloadType_PAYLOAD_TYPE_ZEROS:
+		return make([]byte, size), nil
+	case pb.PayloadType_PAYLOAD_TYPE_RANDOM:
+		payload := make([]byte, size)
+		if _, err := rand.Read(payload); err != nil {
+			return nil, status.Errorf(codes.Internal, "fail to generate random payload: %v", err)
+		}
+		return payload, nil
+	case pb.PayloadType_PAYLOAD_TYPE_TEXT:
+		text := spec.Text
+		if text == "" {
+			text = _defaultPayloadText
+		}
+		return bytes.Repeat([]byte(text), size/len(text)+1)[:size], nil
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "unknown payload type: %s", spec.Type)
+}
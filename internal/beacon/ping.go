@@ -0,0 +1,77 @@
+package beacon
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+)
+
+// Ping answers every message of the stream as soon as it arrives. The server
+// timestamps are taken right after receiving and right before sending so the
+// client can subtract the time spent on the server from the round trip. The
+// stream ends with Unavailable once the server is stopping.
+func (s *service) Ping(stream pb.BeaconService_PingServer) error {
+	logger := s.requestLogger(stream.Context())
+	logger.Info("Ping stream started")
+
+	// Recv blocks, so it runs aside to watch the stop of the server too.
+	pings := make(chan ping)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			select {
+			case pings <- ping{req: req, received: time.Now(), err: err}:
+			case <-stream.Context().Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var count uint64
+	for {
+		var p ping
+		select {
+		case p = <-pings:
+		case <-s.stopping.Done():
+			logger.Info("Ping stream ended by server stop", zap.Uint64("pings", count))
+			return status.Error(codes.Unavailable, "server is stopping")
+		}
+
+		if errors.Is(p.err, io.EOF) {
+			logger.Info("Ping stream completed", zap.Uint64("pings", count))
+			return nil
+		}
+		if p.err != nil {
+			logger.Info("Ping stream ended by receive failure", zap.Uint64("pings", count), zap.Error(p.err))
+			return p.err
+		}
+
+		resp := &pb.PingResponse{
+			Sequence:          p.req.Sequence,
+			ClientSendTime:    p.req.ClientSendTime,
+			ServerReceiveTime: timestamppb.New(p.received),
+		}
+		resp.ServerSendTime = timestamppb.Now()
+		if err := stream.Send(resp); err != nil {
+			logger.Info("Ping stream ended by send failure", zap.Uint64("pings", count), zap.Error(err))
+			return err
+		}
+		count++
+	}
+}
+
+type ping struct {
+	req      *pb.PingRequest
+	received time.Time
+	err      error
+}
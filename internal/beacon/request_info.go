@@ -0,0 +1,97 @@
+package beacon
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+)
+
+func newRequestInfo(ctx context.Context, redactor *logging.Redactor) *pb.RequestInfo {
+	info := &pb.RequestInfo{}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		md = redactor.Redact(md)
+		info.Headers = make(map[string]*pb.HeaderValues, len(md))
+		for k, v := range md {
+			info.Headers[k] = &pb.HeaderValues{Values: v}
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		info.Peer = newPeerInfo(p)
+		if id, ok := rpc.PeerIdentityFromContext(ctx); ok && info.Peer.Tls != nil {
+			info.Peer.Tls.ClientIdentity = &pb.ClientIdentity{
+				Subject:    id.Subject,
+				CommonName: id.CommonName,
+				Sans:       id.SANs,
+				SpiffeId:   id.SPIFFEID,
+			}
+		}
+		if cert, ok := rpc.ServedCertificateFromContext(ctx); ok && info.Peer.Tls != nil {
+			info.Peer.Tls.ServerCertificate = &pb.CertificateInfo{
+				Subject:  cert.Subject.String(),
+				Serial:   cert.SerialNumber.String(),
+				DnsNames: cert.DNSNames,
+				NotAfter: timestamppb.New(cert.NotAfter),
+			}
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		info.Deadline = durationpb.New(time.Until(deadline))
+	}
+
+	// The transport stream of grpc-go exposes the compression of the request,
+	// which is not otherwise available to handlers.
+	if s, ok := grpc.ServerTransportStreamFromContext(ctx).(interface{ RecvCompress() string }); ok {
+		info.Compressor = s.RecvCompress()
+	}
+	if accepted, err := grpc.ClientSupportedCompressors(ctx); err == nil {
+		info.AcceptedCompressors = accepted
+	}
+
+	return info
+}
+
+func newPeerInfo(p *peer.Peer) *pb.PeerInfo {
+	info := &pb.PeerInfo{}
+	if p.Addr != nil {
+		info.Address = p.Addr.String()
+	}
+	if p.LocalAddr != nil {
+		info.LocalAddress = p.LocalAddr.String()
+	}
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		info.Tls = newTLSInfo(tlsInfo.State)
+	}
+
+	return info
+}
+
+func newTLSInfo(state tls.ConnectionState) *pb.TLSInfo {
+	info := &pb.TLSInfo{
+		Version:            tls.VersionName(state.Version),
+		CipherSuite:        tls.CipherSuiteName(state.CipherSuite),
+		ServerName:         state.ServerName,
+		NegotiatedProtocol: state.NegotiatedProtocol,
+		DidResume:          state.DidResume,
+	}
+
+	if len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 0 {
+		info.ClientCertificateSubject = state.VerifiedChains[0][0].Subject.String()
+	}
+
+	return info
+}
@@ -6,24 +6,35 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/tracing"
 )
 
+const _defaultStreamInterval = time.Second
+
 type service struct {
 	pb.UnimplementedBeaconServiceServer
 
-	details map[string]string
-	logger  *zap.Logger
+	details  map[string]string
+	logger   *zap.Logger
+	redactor *logging.Redactor
+	stopping *rpc.Stopping
 }
 
 var _ pb.BeaconServiceServer = (*service)(nil)
 
-func newService(hostName, beaconName string, logger *zap.Logger) *service {
+func newService(hostName, beaconName string, logger *zap.Logger, redactor *logging.Redactor, stopping *rpc.Stopping) *service {
 	s := &service{
-		details: make(map[string]string),
-		logger:  logger,
+		details:  make(map[string]string),
+		logger:   logger,
+		redactor: redactor,
+		stopping: stopping,
 	}
 
 	s.details["Hostname"] = hostName
@@ -33,19 +44,115 @@ func newService(hostName, beaconName string, logger *zap.Logger) *service {
 }
 
 func (s *service) Signal(ctx context.Context, req *pb.SignalRequest) (*pb.SignalResponse, error) {
-	logger := s.logger
+	logger := s.requestLogger(ctx)
+
+	logger.Info("Signal received")
+
+	// The request info is taken before the fault is injected, so that the
+	// deadline is the one remaining when the call was received.
+	info := newRequestInfo(ctx, s.redactor)
+	f, err := resolveFault(ctx, req.Fault)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := buildPayload(req.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.inject(ctx, logger); err != nil {
+		return nil, err
+	}
+
+	resp := s.newResponse(0)
+	resp.Payload = payload
+	resp.Request = info
+	return resp, nil
+}
+
+func (s *service) SignalStream(req *pb.SignalStreamRequest, stream pb.BeaconService_SignalStreamServer) error {
+	interval := _defaultStreamInterval
+	if req.Interval != nil {
+		if err := req.Interval.CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid interval: %v", err)
+		}
+		if d := req.Interval.AsDuration(); d < 0 {
+			return status.Errorf(codes.InvalidArgument, "interval must not be negative: %s", d)
+		} else if d > 0 {
+			interval = d
+		}
+	}
+
+	ctx := stream.Context()
+	logger := s.requestLogger(ctx).With(
+		zap.Duration("interval", interval),
+		zap.Uint64("count", req.Count),
+	)
+	logger.Info("SignalStream started")
+
+	info := newRequestInfo(ctx, s.redactor)
+	f, err := resolveFault(ctx, req.Fault)
+	if err != nil {
+		return err
+	}
+	payload, err := buildPayload(req.Payload)
+	if err != nil {
+		return err
+	}
+
+	if err := f.inject(ctx, logger); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for seq := uint64(1); ; seq++ {
+		resp := s.newResponse(seq)
+		resp.Payload = payload
+		resp.Request = info
+		if err := stream.Send(resp); err != nil {
+			logger.Info("SignalStream ended by send failure", zap.Uint64("sent", seq-1), zap.Error(err))
+			return err
+		}
+
+		if req.Count != 0 && seq >= req.Count {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("SignalStream cancelled by client", zap.Uint64("sent", seq))
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.stopping.Done():
+			logger.Info("SignalStream ended by server stop", zap.Uint64("sent", seq))
+			return status.Error(codes.Unavailable, "server is stopping")
+		case <-ticker.C:
+		}
+	}
+
+	logger.Info("SignalStream completed")
+	return nil
+}
+
+func (s *service) requestLogger(ctx context.Context) *zap.Logger {
+	logger := s.logger.With(tracing.LogFields(ctx)...)
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		logger = logger.With(zap.Any("metadata", md))
+		logger = logger.With(s.redactor.Field("metadata", md))
 	}
 
-	logger.Info("Signal received")
+	return logger
+}
+
+func (s *service) newResponse(seq uint64) *pb.SignalResponse {
 	resp := &pb.SignalResponse{
-		Reply: fmt.Sprintf("Beacon signal at %s", time.Now().Format(time.RFC1123)),
+		Reply:    fmt.Sprintf("Beacon signal at %s", time.Now().Format(time.RFC1123)),
+		Sequence: seq,
 	}
 
 	if len(s.details) > 0 {
 		resp.Details = s.details
 	}
 
-	return resp, nil
+	return resp
 }
@@ -0,0 +1,310 @@
+// Package certgen generates the certificate authorities, server and client
+// certificates used to test TLS with the beacon.
+package certgen
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	KeyTypeEC  = "ec"
+	KeyTypeRSA = "rsa"
+
+	// DefaultValidity is the validity of certificates unless specified.
+	DefaultValidity = 365 * 24 * time.Hour
+)
+
+// Usage is what a leaf certificate is issued for.
+type Usage int
+
+const (
+	UsageServer Usage = iota
+	UsageClient
+)
+
+type (
+	// Options describes the certificate to generate.
+	Options struct {
+		CommonName string
+		// SANs are DNS names, IP addresses, email addresses or URIs, e.g.
+		// spiffe://example.org/frontend. See ParseSANs.
+		SANs []string
+		// KeyType is ec or rsa, ec by default.
+		KeyType string
+		// KeySize is the RSA modulus size, 2048 by default, or the EC curve
+		// size, 256 by default.
+		KeySize int
+		// NotBefore defaults to one minute ago, to tolerate clock skew.
+		NotBefore time.Time
+		Validity  time.Duration
+		Usage     Usage
+	}
+
+	// Certificate is a generated certificate with its key. Chain holds the
+	// intermediate certificates between the certificate and the root.
+	Certificate struct {
+		Cert  *x509.Certificate
+		Key   crypto.Signer
+		Chain []*x509.Certificate
+	}
+)
+
+// NewCA generates a self-signed certificate authority.
+func NewCA(opts Options) (*Certificate, error) {
+	return generate(opts, nil, true)
+}
+
+// SelfSigned generates a self-signed leaf certificate.
+func SelfSigned(opts Options) (*Certificate, error) {
+	return generate(opts, nil, false)
+}
+
+// Issue generates a leaf certificate signed by the certificate authority.
+func (ca *Certificate) Issue(opts Options) (*Certificate, error) {
+	return generate(opts, ca, false)
+}
+
+// IssueCA generates an intermediate certificate authority signed by the
+// certificate authority.
+func (ca *Certificate) IssueCA(opts Options) (*Certificate, error) {
+	return generate(opts, ca, true)
+}
+
+func generate(opts Options, issuer *Certificate, isCA bool) (*Certificate, error) {
+	key, err := generateKey(opts.KeyType, opts.KeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	if err != nil {
+		return nil, fmt.Errorf("fail to generate serial number: %w", err)
+	}
+
+	notBefore := opts.NotBefore
+	if notBefore.IsZero() {
+		notBefore = time.Now().Add(-time.Minute)
+	}
+	validity := opts.Validity
+	if validity <= 0 {
+		validity = DefaultValidity
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: opts.CommonName},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(validity),
+		BasicConstraintsValid: true,
+	}
+	if err := addSANs(tmpl, opts.SANs); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case isCA:
+		tmpl.IsCA = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	case opts.Usage == UsageClient:
+		tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	default:
+		tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	}
+	if _, ok := key.(*rsa.PrivateKey); ok && !isCA {
+		tmpl.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	parent, signer := tmpl, key
+	var chain []*x509.Certificate
+	if issuer != nil {
+		parent, signer = issuer.Cert, issuer.Key
+		if !isSelfSigned(issuer.Cert) {
+			chain = append([]*x509.Certificate{issuer.Cert}, issuer.Chain...)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), signer)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse certificate: %w", err)
+	}
+
+	return &Certificate{Cert: cert, Key: key, Chain: chain}, nil
+}
+
+func generateKey(keyType string, size int) (crypto.Signer, error) {
+	switch keyType {
+	case "", KeyTypeEC:
+		var curve elliptic.Curve
+		switch size {
+		case 0, 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC key size %d, expected 256, 384 or 521", size)
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case KeyTypeRSA:
+		if size == 0 {
+			size = 2048
+		}
+		if size < 2048 {
+			return nil, fmt.Errorf("unsupported RSA key size %d, expected at least 2048", size)
+		}
+		return rsa.GenerateKey(rand.Reader, size)
+	default:
+		return nil, fmt.Errorf("unknown key type %q, expected %s or %s", keyType, KeyTypeEC, KeyTypeRSA)
+	}
+}
+
+// addSANs sorts the SANs into IP addresses, URIs, email addresses and DNS
+// names.
+func addSANs(tmpl *x509.Certificate, sans []string) error {
+	for _, san := range sans {
+		switch {
+		case net.ParseIP(san) != nil:
+			tmpl.IPAddresses = append(tmpl.IPAddresses, net.ParseIP(san))
+		case strings.Contains(san, "://"):
+			u, err := url.Parse(san)
+			if err != nil {
+				return fmt.Errorf("invalid URI SAN %q: %w", san, err)
+			}
+			tmpl.URIs = append(tmpl.URIs, u)
+		case strings.Contains(san, "@"):
+			tmpl.EmailAddresses = append(tmpl.EmailAddresses, san)
+		case san != "":
+			tmpl.DNSNames = append(tmpl.DNSNames, san)
+		}
+	}
+
+	return nil
+}
+
+// ParseSANs splits a comma separated list of SANs.
+func ParseSANs(s string) []string {
+	var sans []string
+	for _, san := range strings.Split(s, ",") {
+		if san = strings.TrimSpace(san); san != "" {
+			sans = append(sans, san)
+		}
+	}
+
+	return sans
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// TLSCertificate returns the certificate, followed by its chain, for a TLS
+// config.
+func (c *Certificate) TLSCertificate() tls.Certificate {
+	certs := [][]byte{c.Cert.Raw}
+	for _, ic := range c.Chain {
+		certs = append(certs, ic.Raw)
+	}
+
+	return tls.Certificate{Certificate: certs, PrivateKey: c.Key, Leaf: c.Cert}
+}
+
+// CertPEM encodes the certificate, followed by its chain.
+func (c *Certificate) CertPEM() []byte {
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Cert.Raw})
+	for _, ic := range c.Chain {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ic.Raw})...)
+	}
+
+	return data
+}
+
+// KeyPEM encodes the private key in PKCS #8.
+func (c *Certificate) KeyPEM() ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(c.Key)
+	if err != nil {
+		return nil, fmt.Errorf("fail to marshal private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// Fingerprint returns the SHA-256 fingerprint of the certificate.
+func (c *Certificate) Fingerprint() string {
+	sum := sha256.Sum256(c.Cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// WriteFiles writes the certificate to <dir>/<name>.crt.pem and its key to
+// <dir>/<name>.key.pem, which only the owner can read.
+func (c *Certificate) WriteFiles(dir, name string) error {
+	if name == "" {
+		return errors.New("file name is empty")
+	}
+
+	keyPEM, err := c.KeyPEM()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name+".crt.pem"), c.CertPEM(), 0o644); err != nil {
+		return fmt.Errorf("fail to write certificate: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".key.pem"), keyPEM, 0o600); err != nil {
+		return fmt.Errorf("fail to write private key: %w", err)
+	}
+
+	return nil
+}
+
+// LoadFiles reads a certificate and its key written by WriteFiles, e.g. to
+// issue new certificates from an existing certificate authority.
+func LoadFiles(certFilePath, keyFilePath string) (*Certificate, error) {
+	pair, err := tls.LoadX509KeyPair(certFilePath, keyFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("fail to load certificate: %w", err)
+	}
+
+	signer, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("private key cannot sign")
+	}
+
+	c := &Certificate{Cert: pair.Leaf, Key: signer}
+	for _, der := range pair.Certificate[1:] {
+		ic, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("fail to parse chain: %w", err)
+		}
+		c.Chain = append(c.Chain, ic)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,104 @@
+package certgen_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/troydai/grpcbeacon/internal/certgen"
+)
+
+func TestIssue(t *testing.T) {
+	ca, err := certgen.NewCA(certgen.Options{CommonName: "test CA"})
+	require.NoError(t, err)
+	assert.True(t, ca.Cert.IsCA)
+
+	server, err := ca.Issue(certgen.Options{
+		CommonName: "server",
+		SANs:       []string{"localhost", "127.0.0.1", "spiffe://example.org/server", "ops@example.org"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost"}, server.Cert.DNSNames)
+	assert.Equal(t, "127.0.0.1", server.Cert.IPAddresses[0].String())
+	assert.Equal(t, "spiffe://example.org/server", server.Cert.URIs[0].String())
+	assert.Equal(t, []string{"ops@example.org"}, server.Cert.EmailAddresses)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, server.Cert.ExtKeyUsage)
+	assert.WithinDuration(t, time.Now().Add(certgen.DefaultValidity), server.Cert.NotAfter, 2*time.Minute)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+	_, err = server.Cert.Verify(x509.VerifyOptions{DNSName: "localhost", Roots: roots})
+	assert.NoError(t, err)
+
+	client, err := ca.Issue(certgen.Options{CommonName: "client", Usage: certgen.UsageClient})
+	require.NoError(t, err)
+	_, err = client.Cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	assert.NoError(t, err)
+}
+
+func TestIssueFromIntermediate(t *testing.T) {
+	root, err := certgen.NewCA(certgen.Options{CommonName: "root"})
+	require.NoError(t, err)
+	intermediate, err := root.IssueCA(certgen.Options{CommonName: "intermediate"})
+	require.NoError(t, err)
+	leaf, err := intermediate.Issue(certgen.Options{CommonName: "leaf", SANs: []string{"localhost"}})
+	require.NoError(t, err)
+
+	require.Len(t, leaf.Chain, 1)
+	assert.Equal(t, intermediate.Cert, leaf.Chain[0])
+	assert.Len(t, leaf.TLSCertificate().Certificate, 2)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root.Cert)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(leaf.Chain[0])
+	_, err = leaf.Cert.Verify(x509.VerifyOptions{DNSName: "localhost", Roots: roots, Intermediates: intermediates})
+	assert.NoError(t, err)
+}
+
+func TestKeyTypes(t *testing.T) {
+	c, err := certgen.SelfSigned(certgen.Options{KeyType: certgen.KeyTypeRSA})
+	require.NoError(t, err)
+	assert.Equal(t, 2048, c.Key.(*rsa.PrivateKey).N.BitLen())
+
+	c, err = certgen.SelfSigned(certgen.Options{KeySize: 384})
+	require.NoError(t, err)
+	assert.Equal(t, elliptic.P384(), c.Key.(*ecdsa.PrivateKey).Curve)
+
+	_, err = certgen.SelfSigned(certgen.Options{KeyType: "dsa"})
+	assert.EqualError(t, err, `unknown key type "dsa", expected ec or rsa`)
+
+	_, err = certgen.SelfSigned(certgen.Options{KeyType: certgen.KeyTypeRSA, KeySize: 1024})
+	assert.Error(t, err)
+}
+
+func TestWriteAndLoadFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	root, err := certgen.NewCA(certgen.Options{CommonName: "root"})
+	require.NoError(t, err)
+	intermediate, err := root.IssueCA(certgen.Options{CommonName: "intermediate"})
+	require.NoError(t, err)
+	require.NoError(t, intermediate.WriteFiles(dir, "ca"))
+
+	loaded, err := certgen.LoadFiles(filepath.Join(dir, "ca.crt.pem"), filepath.Join(dir, "ca.key.pem"))
+	require.NoError(t, err)
+	assert.Equal(t, intermediate.Cert.Raw, loaded.Cert.Raw)
+	assert.Empty(t, loaded.Chain, "the root is not part of the chain")
+
+	leaf, err := loaded.Issue(certgen.Options{CommonName: "leaf"})
+	require.NoError(t, err)
+	assert.NoError(t, leaf.Cert.CheckSignatureFrom(intermediate.Cert))
+}
+
+func TestParseSANs(t *testing.T) {
+	assert.Equal(t, []string{"localhost", "::1"}, certgen.ParseSANs(" localhost,, ::1 "))
+	assert.Nil(t, certgen.ParseSANs(""))
+}
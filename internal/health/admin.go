@@ -0,0 +1,127 @@
+package health
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthapi "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+)
+
+const _bearerPrefix = "bearer "
+
+// admin implements the AdminService on top of the health registry so that
+// services can be flipped between statuses at runtime.
+type admin struct {
+	pb.UnimplementedAdminServiceServer
+
+	registry *Registry
+	token    string
+	logger   *zap.Logger
+}
+
+var _ pb.AdminServiceServer = (*admin)(nil)
+var _ rpc.GRPCRegister = (*admin)(nil)
+
+func (s *admin) SetServingStatus(ctx context.Context, req *pb.SetServingStatusRequest) (*pb.SetServingStatusResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	st, ok := fromServingStatus(req.Status)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid serving status: %s", req.Status)
+	}
+
+	resp := &pb.SetServingStatusResponse{}
+	if previous, ok := s.registry.Status(req.Service); ok {
+		resp.PreviousStatus = toServingStatus(previous)
+	}
+
+	s.registry.SetServingStatus(req.Service, st)
+	s.logger.Info(
+		"serving status changed by admin",
+		zap.String("service", req.Service),
+		zap.Stringer("status", st),
+		zap.Stringer("previous", resp.PreviousStatus),
+	)
+
+	return resp, nil
+}
+
+func (s *admin) ListServingStatus(ctx context.Context, _ *pb.ListServingStatusRequest) (*pb.ListServingStatusResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	statuses := s.registry.Statuses()
+	resp := &pb.ListServingStatusResponse{Statuses: make(map[string]pb.ServingStatus, len(statuses))}
+	for service, st := range statuses {
+		resp.Statuses[service] = toServingStatus(st)
+	}
+
+	return resp, nil
+}
+
+func (s *admin) Register(server grpc.ServiceRegistrar) error {
+	if s == nil {
+		return fmt.Errorf("admin service is nil")
+	}
+
+	pb.RegisterAdminServiceServer(server, s)
+	return nil
+}
+
+func (s *admin) authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+
+	value := values[0]
+	if len(value) < len(_bearerPrefix) || !strings.EqualFold(value[:len(_bearerPrefix)], _bearerPrefix) {
+		return status.Error(codes.Unauthenticated, "authorization header is not a bearer token")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(value[len(_bearerPrefix):]), []byte(s.token)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid admin token")
+	}
+
+	return nil
+}
+
+func fromServingStatus(st pb.ServingStatus) (Status, bool) {
+	switch st {
+	case pb.ServingStatus_SERVING_STATUS_SERVING:
+		return healthapi.HealthCheckResponse_SERVING, true
+	case pb.ServingStatus_SERVING_STATUS_NOT_SERVING:
+		return healthapi.HealthCheckResponse_NOT_SERVING, true
+	case pb.ServingStatus_SERVING_STATUS_SERVICE_UNKNOWN:
+		return healthapi.HealthCheckResponse_SERVICE_UNKNOWN, true
+	}
+
+	return 0, false
+}
+
+func toServingStatus(st Status) pb.ServingStatus {
+	switch st {
+	case healthapi.HealthCheckResponse_SERVING:
+		return pb.ServingStatus_SERVING_STATUS_SERVING
+	case healthapi.HealthCheckResponse_NOT_SERVING:
+		return pb.ServingStatus_SERVING_STATUS_NOT_SERVING
+	case healthapi.HealthCheckResponse_SERVICE_UNKNOWN:
+		return pb.ServingStatus_SERVING_STATUS_SERVICE_UNKNOWN
+	}
+
+	return pb.ServingStatus_SERVING_STATUS_UNSPECIFIED
+}
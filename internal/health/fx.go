@@ -1,19 +1,82 @@
 package health
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
+	healthapi "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
 )
 
-var Module = fx.Options(fx.Provide(ProvideHealthCheckService))
+var Module = fx.Options(fx.Provide(ProvideRegistry, ProvideHealthCheckService))
+
+type (
+	Param struct {
+		fx.In
+
+		Registry   *Registry
+		Env        settings.Environment
+		Config     settings.Configuration
+		Logger     *zap.Logger
+		Registerer prometheus.Registerer `optional:"true"`
+		Stopping   *rpc.Stopping         `optional:"true"`
+	}
+
+	Result struct {
+		fx.Out
+
+		Registers []rpc.GRPCRegister `group:"grpc_registers,flatten"`
+		Services  rpc.ServiceRegistry
+	}
+)
 
-type Result struct {
-	fx.Out
+// ProvideRegistry returns a registry where the overall, liveness, readiness
+// and Beacon statuses are serving.
+func ProvideRegistry() *Registry {
+	r := NewRegistry()
+	for _, service := range []string{"", "liveness", "readiness", "Beacon"} {
+		r.SetServingStatus(service, healthapi.HealthCheckResponse_SERVING)
+	}
 
-	Register rpc.GRPCRegister `group:"grpc_registers"`
+	return r
 }
 
-func ProvideHealthCheckService() Result {
-	return Result{Register: &healthcheck{}}
+// ProvideHealthCheckService registers the health service and, when enabled,
+// the admin service that controls the statuses reported by it.
+func ProvideHealthCheckService(param Param) (Result, error) {
+	result := Result{
+		Registers: []rpc.GRPCRegister{&healthcheck{registry: param.Registry, stopping: param.Stopping}},
+		Services:  param.Registry,
+	}
+
+	if param.Registerer != nil {
+		if err := param.Registerer.Register(&statusCollector{registry: param.Registry}); err != nil {
+			return Result{}, fmt.Errorf("fail to register health metrics: %w", err)
+		}
+	}
+
+	if param.Config.Admin == nil || !param.Config.Admin.Enabled {
+		return result, nil
+	}
+
+	token := param.Env.AdminToken
+	if token == "" {
+		token = param.Config.Admin.Token
+	}
+	if token == "" {
+		return Result{}, errors.New("admin service is enabled without a token")
+	}
+
+	result.Registers = append(result.Registers, &admin{
+		registry: param.Registry,
+		token:    token,
+		logger:   param.Logger,
+	})
+
+	return result, nil
 }
@@ -0,0 +1,43 @@
+package health
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	healthapi "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var _statusDesc = prometheus.NewDesc(
+	"grpcbeacon_health_status",
+	"Serving status of every service known to the health registry. The series of the current status is 1, the others are 0.",
+	[]string{"service", "status"},
+	nil,
+)
+
+var _reportedStatuses = []Status{
+	healthapi.HealthCheckResponse_SERVING,
+	healthapi.HealthCheckResponse_NOT_SERVING,
+	healthapi.HealthCheckResponse_SERVICE_UNKNOWN,
+}
+
+// statusCollector exposes the statuses of the registry as gauges.
+type statusCollector struct {
+	registry *Registry
+}
+
+var _ prometheus.Collector = (*statusCollector)(nil)
+
+func (c *statusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- _statusDesc
+}
+
+func (c *statusCollector) Collect(ch chan<- prometheus.Metric) {
+	for service, current := range c.registry.Statuses() {
+		for _, st := range _reportedStatuses {
+			var value float64
+			if st == current {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(_statusDesc, prometheus.GaugeValue, value, service, st.String())
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package health
+
+import (
+	"sync"
+
+	healthapi "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/troydai/grpcbeacon/internal/rpc"
+)
+
+type Status = healthapi.HealthCheckResponse_ServingStatus
+
+// Registry keeps the serving status of every service and notifies the
+// watchers of a service whenever its status changes.
+type Registry struct {
+	mu       sync.Mutex
+	statuses map[string]Status
+	watchers map[string]map[chan Status]struct{}
+}
+
+var _ rpc.ServiceRegistry = (*Registry)(nil)
+
+func NewRegistry() *Registry {
+	return &Registry{
+		statuses: make(map[string]Status),
+		watchers: make(map[string]map[chan Status]struct{}),
+	}
+}
+
+// Status returns the serving status of the service and whether the service
+// is known to the registry.
+func (r *Registry) Status(service string) (Status, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.statuses[service]
+	return s, ok
+}
+
+// AddService adds the service to the registry as serving. The status of a
+// service already in the registry is left untouched.
+func (r *Registry) AddService(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.statuses[name]; ok {
+		return
+	}
+
+	r.statuses[name] = healthapi.HealthCheckResponse_SERVING
+	for w := range r.watchers[name] {
+		notify(w, healthapi.HealthCheckResponse_SERVING)
+	}
+}
+
+// Statuses returns a snapshot of the status of every known service.
+func (r *Registry) Statuses() map[string]Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make(map[string]Status, len(r.statuses))
+	for k, v := range r.statuses {
+		statuses[k] = v
+	}
+
+	return statuses
+}
+
+// SetServingStatus records the status of the service, adding the service to
+// the registry if it is not known yet.
+func (r *Registry) SetServingStatus(service string, status Status) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statuses[service] = status
+	for w := range r.watchers[service] {
+		notify(w, status)
+	}
+}
+
+// Shutdown marks every known service as not serving.
+func (r *Registry) Shutdown() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for service := range r.statuses {
+		r.statuses[service] = healthapi.HealthCheckResponse_NOT_SERVING
+		for w := range r.watchers[service] {
+			notify(w, healthapi.HealthCheckResponse_NOT_SERVING)
+		}
+	}
+}
+
+// Watch returns a channel that receives the current status of the service
+// immediately and the latest status after every change. A watcher that falls
+// behind only observes the most recent status. The returned function stops
+// the watch and must be called once the watcher is done.
+func (r *Registry) Watch(service string) (<-chan Status, func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w := make(chan Status, 1)
+	status, ok := r.statuses[service]
+	if !ok {
+		status = healthapi.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+	w <- status
+
+	if r.watchers[service] == nil {
+		r.watchers[service] = make(map[chan Status]struct{})
+	}
+	r.watchers[service][w] = struct{}{}
+
+	return w, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		delete(r.watchers[service], w)
+		if len(r.watchers[service]) == 0 {
+			delete(r.watchers, service)
+		}
+	}
+}
+
+// notify replaces any status the watcher has not consumed yet with the
+// latest one. It must be called with the registry lock held.
+func notify(w chan Status, status Status) {
+	select {
+	case <-w:
+	default:
+	}
+	w <- status
+}
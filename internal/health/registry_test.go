@@ -0,0 +1,110 @@
+package health_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	healthapi "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/troydai/grpcbeacon/internal/health"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("status of unknown service", func(t *testing.T) {
+		r := health.NewRegistry()
+
+		_, ok := r.Status("unknown")
+		assert.False(t, ok)
+	})
+
+	t.Run("set and get status", func(t *testing.T) {
+		r := health.NewRegistry()
+		r.SetServingStatus("svc", healthapi.HealthCheckResponse_NOT_SERVING)
+
+		st, ok := r.Status("svc")
+		assert.True(t, ok)
+		assert.Equal(t, healthapi.HealthCheckResponse_NOT_SERVING, st)
+	})
+
+	t.Run("watch unknown service", func(t *testing.T) {
+		r := health.NewRegistry()
+		updates, stop := r.Watch("svc")
+		defer stop()
+
+		assert.Equal(t, healthapi.HealthCheckResponse_SERVICE_UNKNOWN, receive(t, updates))
+
+		r.SetServingStatus("svc", healthapi.HealthCheckResponse_SERVING)
+		assert.Equal(t, healthapi.HealthCheckResponse_SERVING, receive(t, updates))
+	})
+
+	t.Run("slow watcher observes the latest status", func(t *testing.T) {
+		r := health.NewRegistry()
+		r.SetServingStatus("svc", healthapi.HealthCheckResponse_SERVING)
+
+		updates, stop := r.Watch("svc")
+		defer stop()
+
+		r.SetServingStatus("svc", healthapi.HealthCheckResponse_NOT_SERVING)
+		r.SetServingStatus("svc", healthapi.HealthCheckResponse_SERVICE_UNKNOWN)
+
+		assert.Equal(t, healthapi.HealthCheckResponse_SERVICE_UNKNOWN, receive(t, updates))
+		select {
+		case st := <-updates:
+			t.Fatalf("unexpected update %s", st)
+		default:
+		}
+	})
+
+	t.Run("concurrent watchers", func(t *testing.T) {
+		r := health.NewRegistry()
+		r.SetServingStatus("svc", healthapi.HealthCheckResponse_SERVING)
+
+		const watchers = 10
+		var ready, done sync.WaitGroup
+		ready.Add(watchers)
+		done.Add(watchers)
+		for i := 0; i < watchers; i++ {
+			go func() {
+				defer done.Done()
+				updates, stop := r.Watch("svc")
+				defer stop()
+
+				assert.Equal(t, healthapi.HealthCheckResponse_SERVING, receive(t, updates))
+				ready.Done()
+				assert.Equal(t, healthapi.HealthCheckResponse_NOT_SERVING, receive(t, updates))
+			}()
+		}
+
+		ready.Wait()
+		r.SetServingStatus("svc", healthapi.HealthCheckResponse_NOT_SERVING)
+		done.Wait()
+	})
+
+	t.Run("stopped watcher is not notified", func(t *testing.T) {
+		r := health.NewRegistry()
+		updates, stop := r.Watch("svc")
+		receive(t, updates)
+		stop()
+
+		r.SetServingStatus("svc", healthapi.HealthCheckResponse_SERVING)
+		select {
+		case st := <-updates:
+			t.Fatalf("unexpected update %s", st)
+		default:
+		}
+	})
+}
+
+func receive(t *testing.T, updates <-chan health.Status) health.Status {
+	t.Helper()
+
+	select {
+	case st := <-updates:
+		return st
+	case <-time.After(time.Second):
+		assert.Fail(t, "no status update received")
+		return -1
+	}
+}
@@ -6,35 +6,69 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthapi "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 
-	healthapi "github.com/troydai/grpcbeacon/gen/go/grpc/health/v1"
 	"github.com/troydai/grpcbeacon/internal/rpc"
 )
 
-var (
-	_healthResp = &healthapi.HealthCheckResponse{Status: healthapi.HealthCheckResponse_SERVING}
-)
-
 type healthcheck struct {
 	healthapi.UnimplementedHealthServer
+
+	registry *Registry
+	stopping *rpc.Stopping
 }
 
 var _ healthapi.HealthServer = (*healthcheck)(nil)
 var _ rpc.GRPCRegister = (*healthcheck)(nil)
 
 func (s *healthcheck) Check(_ context.Context, req *healthapi.HealthCheckRequest) (*healthapi.HealthCheckResponse, error) {
-	switch req.Service {
-	case "", "liveness", "readiness":
-		return _healthResp, nil
-	case "Beacon":
-		return _healthResp, nil
+	if st, ok := s.registry.Status(req.Service); ok {
+		return &healthapi.HealthCheckResponse{Status: st}, nil
 	}
 
 	return nil, status.Errorf(codes.NotFound, "service %s not found", req.Service)
 }
 
-func (s *healthcheck) Register(server *grpc.Server) error {
+func (s *healthcheck) Watch(req *healthapi.HealthCheckRequest, stream healthapi.Health_WatchServer) error {
+	updates, stop := s.registry.Watch(req.Service)
+	defer stop()
+
+	var last *Status
+	send := func(st Status) error {
+		if last != nil && *last == st {
+			return nil
+		}
+		last = &st
+		return stream.Send(&healthapi.HealthCheckResponse{Status: st})
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.stopping.Done():
+			// The watch ends once the drain is over, so that it does not hold
+			// up the graceful stop, after sending the NOT_SERVING status of
+			// the drain if it is still pending.
+			select {
+			case st := <-updates:
+				if err := send(st); err != nil {
+					return err
+				}
+			default:
+			}
+			return status.Error(codes.Unavailable, "server is stopping")
+		case st := <-updates:
+			if err := send(st); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *healthcheck) Register(server grpc.ServiceRegistrar) error {
 	if s == nil {
 		return fmt.Errorf("health check service is nil")
 	}
@@ -0,0 +1,133 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/settings"
+	"github.com/troydai/grpcbeacon/internal/tracing"
+)
+
+// accessLog writes one log line per call once it completes.
+type accessLog struct {
+	logger     *zap.Logger
+	redactor   *logging.Redactor
+	sampleRate float64
+	metadata   bool
+}
+
+func newAccessLog(c settings.Configuration, logger *zap.Logger, redactor *logging.Redactor) (*accessLog, error) {
+	a := &accessLog{logger: logger, redactor: redactor, sampleRate: 1}
+	if c.Interceptors != nil && c.Interceptors.AccessLog != nil {
+		a.metadata = c.Interceptors.AccessLog.Metadata
+
+		if rate := c.Interceptors.AccessLog.SampleRate; rate != nil {
+			if *rate < 0 || *rate > 1 {
+				return nil, fmt.Errorf("access log sample rate must be within [0, 1]: %v", *rate)
+			}
+			a.sampleRate = *rate
+		}
+	}
+
+	return a, nil
+}
+
+func (a *accessLog) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	a.log(ctx, info.FullMethod, start, err,
+		zap.Int("requestBytes", messageSize(req)),
+		zap.Int("responseBytes", messageSize(resp)),
+	)
+
+	return resp, err
+}
+
+func (a *accessLog) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	counted := &countingStream{ServerStream: ss}
+	err := handler(srv, counted)
+
+	a.log(ss.Context(), info.FullMethod, start, err,
+		zap.Int("requestMessages", counted.received),
+		zap.Int("requestBytes", counted.receivedBytes),
+		zap.Int("responseMessages", counted.sent),
+		zap.Int("responseBytes", counted.sentBytes),
+	)
+
+	return err
+}
+
+func (a *accessLog) log(ctx context.Context, method string, start time.Time, err error, fields ...zap.Field) {
+	code := status.Code(err)
+	if code == codes.OK && a.sampleRate < 1 && rand.Float64() >= a.sampleRate {
+		return
+	}
+
+	fields = append(fields,
+		zap.String("method", method),
+		zap.Stringer("code", code),
+		zap.Duration("latency", time.Since(start)),
+	)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, zap.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		fields = append(fields, zap.String("error", status.Convert(err).Message()))
+	}
+	if a.metadata {
+		md, _ := metadata.FromIncomingContext(ctx)
+		fields = append(fields, a.redactor.Field("metadata", md))
+	}
+	fields = append(fields, tracing.LogFields(ctx)...)
+
+	a.logger.Info("access", fields...)
+}
+
+// countingStream counts the messages and bytes going through a stream.
+type countingStream struct {
+	grpc.ServerStream
+
+	received, receivedBytes int
+	sent, sentBytes         int
+}
+
+func (s *countingStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received++
+		s.receivedBytes += messageSize(m)
+	}
+
+	return err
+}
+
+func (s *countingStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+		s.sentBytes += messageSize(m)
+	}
+
+	return err
+}
+
+func messageSize(m any) int {
+	if msg, ok := m.(proto.Message); ok {
+		return proto.Size(msg)
+	}
+
+	return 0
+}
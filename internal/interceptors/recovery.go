@@ -0,0 +1,48 @@
+package interceptors
+
+import (
+	"context"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recovery turns a panic in a handler into an Internal status. The panic
+// value and the stack trace are logged but not returned to the client.
+type recovery struct {
+	logger *zap.Logger
+}
+
+func (r *recovery) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = r.recovered(info.FullMethod, p)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func (r *recovery) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = r.recovered(info.FullMethod, p)
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func (r *recovery) recovered(method string, p any) error {
+	r.logger.Error(
+		"panic recovered",
+		zap.String("method", method),
+		zap.Any("panic", p),
+		zap.ByteString("stack", debug.Stack()),
+	)
+
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,61 @@
+package interceptors
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+const (
+	Recovery  = "recovery"
+	AccessLog = "accesslog"
+)
+
+var _defaultChain = []string{Recovery}
+
+// ServerChain holds the server interceptors in the order they run.
+type ServerChain struct {
+	Unary  []grpc.UnaryServerInterceptor
+	Stream []grpc.StreamServerInterceptor
+}
+
+// NewServerChain builds the interceptors named in the configuration.
+func NewServerChain(c settings.Configuration, logger *zap.Logger, redactor *logging.Redactor) (ServerChain, error) {
+	names := _defaultChain
+	if c.Interceptors != nil && len(c.Interceptors.Chain) > 0 {
+		names = c.Interceptors.Chain
+	}
+
+	var chain ServerChain
+	for _, name := range names {
+		switch name {
+		case Recovery:
+			r := &recovery{logger: logger}
+			chain.Unary = append(chain.Unary, r.unary)
+			chain.Stream = append(chain.Stream, r.stream)
+		case AccessLog:
+			a, err := newAccessLog(c, logger, redactor)
+			if err != nil {
+				return ServerChain{}, err
+			}
+			chain.Unary = append(chain.Unary, a.unary)
+			chain.Stream = append(chain.Stream, a.stream)
+		default:
+			return ServerChain{}, fmt.Errorf("unknown interceptor %q", name)
+		}
+	}
+
+	return chain, nil
+}
+
+// ServerOptions returns the server options installing the chain.
+func (c ServerChain) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(c.Unary...),
+		grpc.ChainStreamInterceptor(c.Stream...),
+	}
+}
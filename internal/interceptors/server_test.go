@@ -0,0 +1,198 @@
+package interceptors_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/interceptors"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+var (
+	_unaryInfo = &grpc.UnaryServerInfo{FullMethod: "/troydai.grpcbeacon.v1.BeaconService/Signal"}
+	_redactor  = mustRedactor(settings.Configuration{})
+)
+
+func TestNewServerChain(t *testing.T) {
+	t.Run("default chain recovers", func(t *testing.T) {
+		chain, err := interceptors.NewServerChain(settings.Configuration{}, zap.NewNop(), _redactor)
+		require.NoError(t, err)
+		assert.Len(t, chain.Unary, 1)
+		assert.Len(t, chain.Stream, 1)
+	})
+
+	t.Run("configured order", func(t *testing.T) {
+		chain, err := interceptors.NewServerChain(settings.Configuration{
+			Interceptors: &settings.Interceptors{Chain: []string{"accesslog", "recovery"}},
+		}, zap.NewNop(), _redactor)
+		require.NoError(t, err)
+		assert.Len(t, chain.Unary, 2)
+		assert.Len(t, chain.Stream, 2)
+	})
+
+	t.Run("unknown interceptor", func(t *testing.T) {
+		_, err := interceptors.NewServerChain(settings.Configuration{
+			Interceptors: &settings.Interceptors{Chain: []string{"recovery", "auth"}},
+		}, zap.NewNop(), _redactor)
+		assert.ErrorContains(t, err, `unknown interceptor "auth"`)
+	})
+
+	t.Run("invalid sample rate", func(t *testing.T) {
+		_, err := interceptors.NewServerChain(settings.Configuration{
+			Interceptors: &settings.Interceptors{
+				Chain:     []string{"accesslog"},
+				AccessLog: &settings.AccessLog{SampleRate: proto.Float64(2)},
+			},
+		}, zap.NewNop(), _redactor)
+		assert.Error(t, err)
+	})
+}
+
+func TestRecovery(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	chain, err := interceptors.NewServerChain(settings.Configuration{}, zap.New(core), _redactor)
+	require.NoError(t, err)
+
+	_, err = chain.Unary[0](context.Background(), &pb.SignalRequest{}, _unaryInfo, func(context.Context, any) (any, error) {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NotContains(t, err.Error(), "boom")
+
+	entries := logs.FilterMessage("panic recovered").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "boom", fields["panic"])
+	assert.Contains(t, fields["stack"], "runtime/debug.Stack")
+}
+
+func TestAccessLog(t *testing.T) {
+	newChain := func(t *testing.T, sampleRate *float64) (interceptors.ServerChain, *observer.ObservedLogs) {
+		core, logs := observer.New(zap.InfoLevel)
+		chain, err := interceptors.NewServerChain(settings.Configuration{
+			Interceptors: &settings.Interceptors{
+				Chain:     []string{"accesslog"},
+				AccessLog: &settings.AccessLog{SampleRate: sampleRate, Metadata: true},
+			},
+		}, zap.New(core), _redactor)
+		require.NoError(t, err)
+		return chain, logs
+	}
+
+	t.Run("logs the call", func(t *testing.T) {
+		chain, logs := newChain(t, nil)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			"authorization", "Bearer secret",
+			"x-request-id", "abc",
+		))
+		req := &pb.SignalRequest{Message: "hello"}
+		resp := &pb.SignalResponse{Reply: "hello back"}
+		_, err := chain.Unary[0](ctx, req, _unaryInfo, func(context.Context, any) (any, error) {
+			return resp, nil
+		})
+		require.NoError(t, err)
+
+		entries := logs.FilterMessage("access").All()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, _unaryInfo.FullMethod, fields["method"])
+		assert.Equal(t, "OK", fields["code"])
+		assert.EqualValues(t, 7, fields["requestBytes"])
+		assert.EqualValues(t, 12, fields["responseBytes"])
+		assert.Contains(t, fields, "latency")
+		assert.Equal(t, metadata.Pairs("x-request-id", "abc"), fields["metadata"])
+	})
+
+	t.Run("failed calls are always logged", func(t *testing.T) {
+		chain, logs := newChain(t, proto.Float64(1e-9))
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+		for i := 0; i < 10; i++ {
+			_, _ = chain.Unary[0](ctx, &pb.SignalRequest{}, _unaryInfo, func(context.Context, any) (any, error) {
+				return nil, status.Error(codes.Unavailable, "unavailable")
+			})
+		}
+		_, err := chain.Unary[0](ctx, &pb.SignalRequest{}, _unaryInfo, func(context.Context, any) (any, error) {
+			return &pb.SignalResponse{}, nil
+		})
+		require.NoError(t, err)
+
+		entries := logs.FilterMessage("access").All()
+		assert.Len(t, entries, 10)
+		for _, e := range entries {
+			assert.Equal(t, "Unavailable", e.ContextMap()["code"])
+		}
+	})
+
+	t.Run("zero rate logs no successful call", func(t *testing.T) {
+		chain, logs := newChain(t, proto.Float64(0))
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+		for i := 0; i < 10; i++ {
+			_, err := chain.Unary[0](ctx, &pb.SignalRequest{}, _unaryInfo, func(context.Context, any) (any, error) {
+				return &pb.SignalResponse{}, nil
+			})
+			require.NoError(t, err)
+		}
+		_, _ = chain.Unary[0](ctx, &pb.SignalRequest{}, _unaryInfo, func(context.Context, any) (any, error) {
+			return nil, status.Error(codes.Unavailable, "unavailable")
+		})
+
+		entries := logs.FilterMessage("access").All()
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Unavailable", entries[0].ContextMap()["code"])
+	})
+
+	t.Run("stream counts messages", func(t *testing.T) {
+		chain, logs := newChain(t, nil)
+
+		info := &grpc.StreamServerInfo{FullMethod: "/troydai.grpcbeacon.v1.BeaconService/SignalStream"}
+		err := chain.Stream[0](nil, &fakeStream{ctx: context.Background()}, info, func(_ any, ss grpc.ServerStream) error {
+			for i := 0; i < 3; i++ {
+				if err := ss.SendMsg(&pb.SignalResponse{Reply: "abc"}); err != nil {
+					return err
+				}
+			}
+			return errors.New("stream broke")
+		})
+		require.Error(t, err)
+
+		entries := logs.FilterMessage("access").All()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		assert.EqualValues(t, 3, fields["responseMessages"])
+		assert.EqualValues(t, 15, fields["responseBytes"])
+		assert.Equal(t, "Unknown", fields["code"])
+	})
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+func (s *fakeStream) SendMsg(any) error        { return nil }
+
+func mustRedactor(c settings.Configuration) *logging.Redactor {
+	r, err := logging.NewRedactor(c)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
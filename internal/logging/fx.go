@@ -8,7 +8,7 @@ import (
 )
 
 var Module = fx.Options(
-	fx.Provide(NewLogger),
+	fx.Provide(NewLogger, NewRedactor),
 	fx.WithLogger(func(logger *zap.Logger) fxevent.Logger {
 		return &fxevent.ZapLogger{Logger: logger}
 	}),
@@ -0,0 +1,130 @@
+package logging
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+const (
+	RedactionDrop = "drop"
+	RedactionHash = "hash"
+)
+
+// _defaultDenylist covers the headers that commonly carry credentials.
+var _defaultDenylist = []string{
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+	"x-api-key",
+	"api-key",
+	"x-auth-token",
+	"x-csrf-token",
+	"x-xsrf-token",
+	"x-amz-security-token",
+	"x-goog-iap-jwt-assertion",
+}
+
+// _processKey keys the digests of the redactors without a configured key. The
+// digests are consistent within the process only.
+var _processKey = sync.OnceValue(func() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+})
+
+// Redactor removes or hashes sensitive request metadata. Every log line built
+// from request metadata must go through it.
+type Redactor struct {
+	hashKey []byte
+	allow   []string
+	deny    []string
+}
+
+func NewRedactor(c settings.Configuration) (*Redactor, error) {
+	r := &Redactor{deny: _defaultDenylist}
+	if c.Logging == nil || c.Logging.Redaction == nil {
+		return r, nil
+	}
+
+	cfg := c.Logging.Redaction
+	switch cfg.Mode {
+	case "", RedactionDrop:
+	case RedactionHash:
+		r.hashKey = []byte(cfg.HashKey)
+		if len(r.hashKey) == 0 {
+			r.hashKey = _processKey()
+		}
+	default:
+		return nil, fmt.Errorf("unknown redaction mode %q", cfg.Mode)
+	}
+
+	r.allow = lowerAll(cfg.Allow)
+	r.deny = append(lowerAll(cfg.Deny), _defaultDenylist...)
+
+	return r, nil
+}
+
+// Field returns a log field of the redacted metadata.
+func (r *Redactor) Field(key string, md metadata.MD) zap.Field {
+	return zap.Any(key, r.Redact(md))
+}
+
+// Redact returns a copy of md without the sensitive headers, or with their
+// values hashed in hash mode.
+func (r *Redactor) Redact(md metadata.MD) metadata.MD {
+	redacted := make(metadata.MD, len(md))
+	for k, v := range md {
+		if !r.redacted(k) {
+			redacted[k] = v
+			continue
+		}
+
+		if r.hashKey != nil {
+			redacted[k] = r.hashAll(v)
+		}
+	}
+
+	return redacted
+}
+
+func (r *Redactor) redacted(key string) bool {
+	key = strings.ToLower(key)
+	if settings.MatchAny(r.deny, key) {
+		return true
+	}
+
+	return len(r.allow) > 0 && !settings.MatchAny(r.allow, key)
+}
+
+// hashAll replaces the values with a keyed digest, so that low-entropy
+// secrets cannot be recovered from the logs by brute force.
+func (r *Redactor) hashAll(values []string) []string {
+	hashed := make([]string, len(values))
+	for i, v := range values {
+		mac := hmac.New(sha256.New, r.hashKey)
+		mac.Write([]byte(v))
+		hashed[i] = "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil)[:8])
+	}
+
+	return hashed
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, v := range values {
+		lowered[i] = strings.ToLower(v)
+	}
+
+	return lowered
+}
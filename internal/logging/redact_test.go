@@ -0,0 +1,87 @@
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+func TestRedactor(t *testing.T) {
+	md := metadata.Pairs(
+		"authorization", "Bearer secret",
+		"cookie", "session=1",
+		"x-tenant-secret", "s3cr3t",
+		"x-request-id", "abc",
+		"user-agent", "grpc-go",
+	)
+
+	testcases := []struct {
+		name      string
+		redaction *settings.Redaction
+		expected  metadata.MD
+	}{
+		{
+			name:     "default denylist",
+			expected: metadata.Pairs("x-tenant-secret", "s3cr3t", "x-request-id", "abc", "user-agent", "grpc-go"),
+		},
+		{
+			name:      "extra denylist with prefix",
+			redaction: &settings.Redaction{Deny: []string{"X-Tenant-*"}},
+			expected:  metadata.Pairs("x-request-id", "abc", "user-agent", "grpc-go"),
+		},
+		{
+			name:      "allowlist",
+			redaction: &settings.Redaction{Allow: []string{"x-request-id", "authorization"}},
+			expected:  metadata.Pairs("x-request-id", "abc"),
+		},
+		{
+			name:      "hash",
+			redaction: &settings.Redaction{Mode: "hash", HashKey: "correlate", Allow: []string{"x-request-id", "user-agent"}},
+			expected: metadata.MD{
+				"authorization":   {"hmac-sha256:fae65f9285170d08"},
+				"cookie":          {"hmac-sha256:b407c04a2b337d72"},
+				"x-tenant-secret": {"hmac-sha256:c26fa1e3375bedf5"},
+				"x-request-id":    {"abc"},
+				"user-agent":      {"grpc-go"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := logging.NewRedactor(settings.Configuration{
+				Logging: &settings.Logging{Redaction: tc.redaction},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, r.Redact(md))
+		})
+	}
+
+	t.Run("hash with the process key", func(t *testing.T) {
+		newRedactor := func() *logging.Redactor {
+			r, err := logging.NewRedactor(settings.Configuration{
+				Logging: &settings.Logging{Redaction: &settings.Redaction{Mode: "hash"}},
+			})
+			require.NoError(t, err)
+			return r
+		}
+
+		hashed := newRedactor().Redact(md).Get("authorization")
+		require.Len(t, hashed, 1)
+		assert.Regexp(t, `^hmac-sha256:[0-9a-f]{16}$`, hashed[0])
+		assert.Equal(t, hashed, newRedactor().Redact(md).Get("authorization"))
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := logging.NewRedactor(settings.Configuration{
+			Logging: &settings.Logging{Redaction: &settings.Redaction{Mode: "mask"}},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+var Module = fx.Options(
+	fx.Provide(ProvideMetrics, RegisterMetricsServer),
+	// The server is created even when nothing depends on it.
+	fx.Invoke(func(*Server) {}),
+)
+
+const (
+	_defaultPath       = "/metrics"
+	_readHeaderTimeout = 10 * time.Second
+)
+
+type (
+	Result struct {
+		fx.Out
+
+		Registry      *prometheus.Registry
+		Registerer    prometheus.Registerer
+		ServerOptions []grpc.ServerOption `group:"grpc_server_options,flatten"`
+	}
+
+	Param struct {
+		fx.In
+
+		Lifecycle fx.Lifecycle
+		Logger    *zap.Logger
+		Config    settings.Configuration
+		Registry  *prometheus.Registry
+	}
+)
+
+// ProvideMetrics creates the registry of the process and the stats handler
+// collecting the gRPC server metrics. Nothing is provided when metrics are
+// disabled, so the other modules skip registering their collectors.
+func ProvideMetrics(c settings.Configuration) (Result, error) {
+	if c.Metrics == nil || !c.Metrics.Enabled {
+		return Result{}, nil
+	}
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collectors.NewGoCollector()); err != nil {
+		return Result{}, fmt.Errorf("fail to register go collector: %w", err)
+	}
+	if err := registry.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})); err != nil {
+		return Result{}, fmt.Errorf("fail to register process collector: %w", err)
+	}
+
+	handler, err := newStatsHandler(registry)
+	if err != nil {
+		return Result{}, fmt.Errorf("fail to create stats handler: %w", err)
+	}
+
+	return Result{
+		Registry:      registry,
+		Registerer:    registry,
+		ServerOptions: []grpc.ServerOption{grpc.StatsHandler(handler)},
+	}, nil
+}
+
+// Server is the HTTP server of the metrics. It is nil when metrics are
+// disabled.
+type Server struct {
+	lis net.Listener
+}
+
+// Addr returns the bound address of the metrics server, which tells the
+// port picked when Port is 0. It returns nil when metrics are disabled or
+// the server is not started.
+func (s *Server) Addr() net.Addr {
+	if s == nil || s.lis == nil {
+		return nil
+	}
+	return s.lis.Addr()
+}
+
+func RegisterMetricsServer(param Param) (*Server, error) {
+	if param.Registry == nil {
+		return nil, nil
+	}
+
+	path := param.Config.Metrics.Path
+	if path == "" {
+		path = _defaultPath
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, promhttp.HandlerFor(param.Registry, promhttp.HandlerOpts{Registry: param.Registry}))
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: _readHeaderTimeout,
+	}
+
+	server := &Server{}
+	// The port is bound on start, so it is not left bound when the app fails
+	// to build.
+	param.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", param.Config.Metrics.Address, param.Config.Metrics.Port))
+			if err != nil {
+				return fmt.Errorf("fail to start metrics listener: %w", err)
+			}
+			server.lis = lis
+
+			param.Logger.Info("serving metrics", zap.Stringer("address", lis.Addr()), zap.String("path", path))
+			go func() {
+				if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					param.Logger.Error("metrics server failed", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if err := srv.Shutdown(ctx); err != nil {
+				return fmt.Errorf("fail to stop metrics server: %w", err)
+			}
+			return nil
+		},
+	})
+
+	return server, nil
+}
@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	_typeUnary        = "unary"
+	_typeClientStream = "client_stream"
+	_typeServerStream = "server_stream"
+	_typeBidiStream   = "bidi_stream"
+)
+
+type rpcTagKey struct{}
+
+// rpcTag is attached to the context of every call to carry its labels from
+// the Begin event to the End event.
+type rpcTag struct {
+	service string
+	method  string
+	typ     string
+}
+
+// statsHandler collects the gRPC server metrics with the same names and
+// labels as the go-grpc-prometheus interceptors, so existing dashboards work
+// with the beacon.
+type statsHandler struct {
+	started     *prometheus.CounterVec
+	handled     *prometheus.CounterVec
+	msgReceived *prometheus.CounterVec
+	msgSent     *prometheus.CounterVec
+	inFlight    *prometheus.GaugeVec
+	handling    *prometheus.HistogramVec
+	connections prometheus.Gauge
+}
+
+var _ stats.Handler = (*statsHandler)(nil)
+
+func newStatsHandler(registerer prometheus.Registerer) (*statsHandler, error) {
+	labels := []string{"grpc_type", "grpc_service", "grpc_method"}
+	h := &statsHandler{
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_started_total",
+			Help: "Total number of RPCs started on the server.",
+		}, labels),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+		}, append(labels, "grpc_code")),
+		msgReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_msg_received_total",
+			Help: "Total number of RPC stream messages received on the server.",
+		}, labels),
+		msgSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_msg_sent_total",
+			Help: "Total number of gRPC stream messages sent by the server.",
+		}, labels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "grpc_server_in_flight",
+			Help: "Number of RPCs currently handled by the server.",
+		}, labels),
+		handling: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Histogram of response latency (seconds) of gRPC that had been application-level handled by the server.",
+			Buckets: prometheus.DefBuckets,
+		}, labels),
+		connections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "grpc_server_connections",
+			Help: "Number of open connections to the server.",
+		}),
+	}
+
+	for _, c := range []prometheus.Collector{h.started, h.handled, h.msgReceived, h.msgSent, h.inFlight, h.handling, h.connections} {
+		if err := registerer.Register(c); err != nil {
+			return nil, fmt.Errorf("fail to register collector: %w", err)
+		}
+	}
+
+	return h, nil
+}
+
+func (h *statsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	service, method := splitMethodName(info.FullMethodName)
+	return context.WithValue(ctx, rpcTagKey{}, &rpcTag{service: service, method: method})
+}
+
+func (h *statsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	tag, ok := ctx.Value(rpcTagKey{}).(*rpcTag)
+	if !ok {
+		return
+	}
+
+	switch s := s.(type) {
+	case *stats.Begin:
+		tag.typ = rpcType(s)
+		h.started.WithLabelValues(tag.typ, tag.service, tag.method).Inc()
+		h.inFlight.WithLabelValues(tag.typ, tag.service, tag.method).Inc()
+	case *stats.InPayload:
+		h.msgReceived.WithLabelValues(tag.typ, tag.service, tag.method).Inc()
+	case *stats.OutPayload:
+		h.msgSent.WithLabelValues(tag.typ, tag.service, tag.method).Inc()
+	case *stats.End:
+		code := status.Code(s.Error).String()
+		h.inFlight.WithLabelValues(tag.typ, tag.service, tag.method).Dec()
+		h.handled.WithLabelValues(tag.typ, tag.service, tag.method, code).Inc()
+		h.handling.WithLabelValues(tag.typ, tag.service, tag.method).Observe(s.EndTime.Sub(s.BeginTime).Seconds())
+	}
+}
+
+func (h *statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *statsHandler) HandleConn(_ context.Context, s stats.ConnStats) {
+	switch s.(type) {
+	case *stats.ConnBegin:
+		h.connections.Inc()
+	case *stats.ConnEnd:
+		h.connections.Dec()
+	}
+}
+
+func rpcType(b *stats.Begin) string {
+	switch {
+	case b.IsClientStream && b.IsServerStream:
+		return _typeBidiStream
+	case b.IsClientStream:
+		return _typeClientStream
+	case b.IsServerStream:
+		return _typeServerStream
+	}
+
+	return _typeUnary
+}
+
+// splitMethodName splits "/package.Service/Method" into its service and
+// method names.
+func splitMethodName(fullMethodName string) (string, string) {
+	fullMethodName = strings.TrimPrefix(fullMethodName, "/")
+	if i := strings.LastIndex(fullMethodName, "/"); i >= 0 {
+		return fullMethodName[:i], fullMethodName[i+1:]
+	}
+
+	return "unknown", "unknown"
+}
@@ -4,16 +4,26 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"sync"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
+	_ "google.golang.org/grpc/encoding/gzip" // register the gzip compressor
 
+	"github.com/troydai/grpcbeacon/internal/interceptors"
+	"github.com/troydai/grpcbeacon/internal/logging"
 	"github.com/troydai/grpcbeacon/internal/settings"
 )
 
-var Module = fx.Invoke(RegisterRPCServer)
+var Module = fx.Options(
+	fx.Provide(RegisterRPCServer, NewStopping),
+	// The server is created even when nothing depends on it.
+	fx.Invoke(func(*Server) {}),
+)
 
 type (
 	Param struct {
@@ -21,71 +31,216 @@ type (
 
 		Lifecycle     fx.Lifecycle
 		Logger        *zap.Logger
+		Redactor      *logging.Redactor
 		GRPCRegisters []GRPCRegister `group:"grpc_registers"`
 		Config        settings.Configuration
+		Services      ServiceRegistry       `optional:"true"`
+		ServerOptions []grpc.ServerOption   `group:"grpc_server_options"`
+		Registerer    prometheus.Registerer `optional:"true"`
+		Listen        ListenFunc            `optional:"true"`
+		Stopping      *Stopping             `optional:"true"`
+
+		// The interceptors run after the ones of the configured chain. The
+		// values of a group are not ordered, so a provider needing an order
+		// chains its interceptors into one.
+		UnaryInterceptors  []grpc.UnaryServerInterceptor  `group:"grpc_unary_interceptors"`
+		StreamInterceptors []grpc.StreamServerInterceptor `group:"grpc_stream_interceptors"`
 	}
 
+	// GRPCRegister registers services. The registrar of a listener only
+	// keeps the services the listener exposes.
 	GRPCRegister interface {
-		Register(*grpc.Server) error
+		Register(grpc.ServiceRegistrar) error
+	}
+
+	// ListenFunc opens the listener of a configuration in place of the
+	// network socket, e.g. an in-memory listener in tests.
+	ListenFunc func(settings.Listener) (net.Listener, error)
+
+	// ServiceRegistry is told the name of every service the GRPCRegisters
+	// add to the server, e.g. to report their health, and when the server
+	// starts shutting down.
+	ServiceRegistry interface {
+		AddService(name string)
+		Shutdown()
 	}
 )
 
-func GRPCRegisterFromFn(fn func(*grpc.Server) error) GRPCRegister {
+func GRPCRegisterFromFn(fn func(grpc.ServiceRegistrar) error) GRPCRegister {
 	return &genericGRPCRegister{fn: fn}
 }
 
-func RegisterRPCServer(param Param) error {
+// RegisterRPCServer opens the listeners and serves them once the app
+// starts. The listeners are bound right away, so the returned server knows
+// their addresses, including the ports picked for port 0, before the app
+// starts.
+func RegisterRPCServer(param Param) (*Server, error) {
 	if len(param.GRPCRegisters) == 0 {
-		return fmt.Errorf("no grpc register found")
+		return nil, fmt.Errorf("no grpc register found")
 	}
 
-	var serverOptions []grpc.ServerOption
+	serverOptions := append([]grpc.ServerOption{}, param.ServerOptions...)
 
-	tlsOpt, err := DetermineTLSOption(param.Config)
+	chain, err := interceptors.NewServerChain(param.Config, param.Logger, param.Redactor)
 	if err != nil {
-		return fmt.Errorf("fail to determine TLS option: %w", err)
+		return nil, fmt.Errorf("fail to build interceptor chain: %w", err)
+	}
+	chain.Unary = append(chain.Unary, param.UnaryInterceptors...)
+	chain.Stream = append(chain.Stream, param.StreamInterceptors...)
+	serverOptions = append(serverOptions, chain.ServerOptions()...)
+
+	var listeners []*listener
+	closeAll := func() {
+		for _, l := range listeners {
+			l.lis.Close()
+		}
 	}
-	if tlsOpt != nil {
-		serverOptions = append(serverOptions, tlsOpt)
+	for i, cfg := range param.Config.ListenerConfigurations() {
+		l, err := newListener(param, cfg, i, serverOptions)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("fail to create listener %s: %w", cfg.Address, err)
+		}
+		listeners = append(listeners, l)
 	}
 
-	s := grpc.NewServer(serverOptions...)
-	reflection.Register(s)
-	for _, r := range param.GRPCRegisters {
-		if err := r.Register(s); err != nil {
-			return fmt.Errorf("fail to register grpc server: %w", err)
+	if param.Registerer != nil {
+		if err := param.Registerer.Register(&certificateCollector{listeners: listeners}); err != nil {
+			closeAll()
+			return nil, fmt.Errorf("fail to register certificate metrics: %w", err)
 		}
 	}
 
-	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", param.Config.Address, param.Config.Port))
-	if err != nil {
-		return fmt.Errorf("fail to start TCP listener: %w", err)
+	if param.Services != nil {
+		for _, l := range listeners {
+			for name := range l.server.GetServiceInfo() {
+				param.Services.AddService(name)
+			}
+		}
 	}
 
-	serverStopped := make(chan struct{})
+	server := &Server{listeners: listeners}
+	stopReload := func() {}
 	param.Lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			go func() {
-				defer close(serverStopped)
-				if err := s.Serve(lis); err != nil {
-					param.Logger.Error("gRPC server failed", zap.Error(err))
+			// The addresses are bound already, so the file is written before
+			// serving and a failure leaves nothing running.
+			if path := param.Config.AddressFile; path != "" {
+				if err := writeAddressFile(path, server.Addrs()); err != nil {
+					closeAll()
+					return err
 				}
-			}()
+				param.Logger.Info("wrote address file", zap.String("path", path))
+			}
+
+			var reloadCtx context.Context
+			reloadCtx, stopReload = context.WithCancel(context.Background())
+
+			for _, l := range listeners {
+				if l.certStore != nil {
+					go l.certStore.Run(reloadCtx)
+				}
+
+				param.Logger.Info(
+					"gRPC server listening",
+					zap.String("listener", l.name),
+					zap.String("network", l.lis.Addr().Network()),
+					zap.String("address", l.lis.Addr().String()),
+					zap.Bool("tls", l.certStore != nil),
+				)
+
+				go func() {
+					defer close(l.stopped)
+					if err := l.server.Serve(l.lis); err != nil {
+						param.Logger.Error("gRPC server failed", zap.String("listener", l.name), zap.Error(err))
+					}
+				}()
+			}
+
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
-			go func() {
-				s.GracefulStop()
-			}()
-
-			select {
-			case <-ctx.Done():
-				return fmt.Errorf("fail to stop daemon in time: %w", ctx.Err())
-			case <-serverStopped:
-				return nil
+			defer stopReload()
+			if path := param.Config.AddressFile; path != "" {
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					param.Logger.Warn("fail to remove address file", zap.Error(err))
+				}
+			}
+			drain(ctx, param)
+			param.Stopping.stop()
+
+			for _, l := range listeners {
+				go l.server.GracefulStop()
+			}
+
+			for _, l := range listeners {
+				select {
+				case <-ctx.Done():
+					param.Logger.Warn("graceful stop timed out, closing all connections")
+					for _, other := range listeners {
+						other.server.Stop()
+						<-other.stopped
+					}
+					return fmt.Errorf("fail to stop daemon in time: %w", ctx.Err())
+				case <-l.stopped:
+				}
 			}
+
+			return nil
 		},
 	})
 
-	return nil
+	return server, nil
+}
+
+// Stopping is closed once the drain period is over, right before the
+// graceful stop. The handlers of long-lived streams return when it is
+// closed, or the graceful stop would wait for them until it times out.
+type Stopping struct {
+	once sync.Once
+	done chan struct{}
+}
+
+func NewStopping() *Stopping {
+	return &Stopping{done: make(chan struct{})}
+}
+
+// Done returns a channel closed when the server stops. It is never closed
+// for a nil Stopping.
+func (s *Stopping) Done() <-chan struct{} {
+	if s == nil {
+		return nil
+	}
+
+	return s.done
+}
+
+func (s *Stopping) stop() {
+	if s != nil {
+		s.once.Do(func() { close(s.done) })
+	}
+}
+
+// drain reports every service as not serving and then waits for the drain
+// period, giving load balancers the time to deregister the server before
+// GOAWAY is sent. It returns early if ctx expires.
+func drain(ctx context.Context, param Param) {
+	if param.Services != nil {
+		param.Services.Shutdown()
+	}
+
+	if param.Config.Shutdown == nil || param.Config.Shutdown.DrainPeriod <= 0 {
+		return
+	}
+
+	period := param.Config.Shutdown.DrainPeriod
+	param.Logger.Info("draining before stop", zap.Duration("period", period))
+
+	timer := time.NewTimer(period)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
 }
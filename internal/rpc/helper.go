@@ -3,11 +3,11 @@ package rpc
 import "google.golang.org/grpc"
 
 type genericGRPCRegister struct {
-	fn func(*grpc.Server) error
+	fn func(grpc.ServiceRegistrar) error
 }
 
 var _ GRPCRegister = (*genericGRPCRegister)(nil)
 
-func (r *genericGRPCRegister) Register(s *grpc.Server) error {
+func (r *genericGRPCRegister) Register(s grpc.ServiceRegistrar) error {
 	return r.fn(s)
 }
@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"context"
+	"crypto/x509"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// PeerIdentity describes the verified client certificate of a call.
+type PeerIdentity struct {
+	Subject    string
+	CommonName string
+	// SANs are the DNS names, email addresses, IP addresses and URIs of the
+	// certificate.
+	SANs []string
+	// SPIFFEID is the spiffe:// URI SAN of the certificate, if any.
+	SPIFFEID string
+}
+
+// PeerIdentityFromContext returns the identity of the verified client
+// certificate of the call. It returns false for plaintext connections and
+// when the client presented no verified certificate.
+func PeerIdentityFromContext(ctx context.Context) (PeerIdentity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return PeerIdentity{}, false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return PeerIdentity{}, false
+	}
+
+	return newPeerIdentity(info.State.VerifiedChains[0][0]), true
+}
+
+func newPeerIdentity(cert *x509.Certificate) PeerIdentity {
+	id := PeerIdentity{
+		Subject:    cert.Subject.String(),
+		CommonName: cert.Subject.CommonName,
+	}
+
+	id.SANs = append(id.SANs, cert.DNSNames...)
+	id.SANs = append(id.SANs, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		id.SANs = append(id.SANs, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		id.SANs = append(id.SANs, uri.String())
+		if uri.Scheme == "spiffe" && id.SPIFFEID == "" {
+			id.SPIFFEID = uri.String()
+		}
+	}
+
+	return id
+}
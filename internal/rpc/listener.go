@@ -0,0 +1,128 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+// listener is a gRPC server serving one configured listener.
+type listener struct {
+	name      string
+	server    *grpc.Server
+	lis       net.Listener
+	certStore *CertStore
+	stopped   chan struct{}
+}
+
+func newListener(param Param, cfg settings.Listener, index int, serverOptions []grpc.ServerOption) (*listener, error) {
+	l := &listener{
+		name:    cfg.Name,
+		stopped: make(chan struct{}),
+	}
+	if l.name == "" {
+		l.name = fmt.Sprintf("#%d", index)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	options := slices.Clone(serverOptions)
+	tlsOpt, certStore, err := DetermineTLSOption(cfg.TLS, param.Logger.With(zap.String("listener", l.name)))
+	if err != nil {
+		return nil, fmt.Errorf("fail to determine TLS option: %w", err)
+	}
+	if tlsOpt != nil {
+		options = append(options, tlsOpt)
+		l.certStore = certStore
+	}
+
+	l.server = grpc.NewServer(options...)
+	registrar := &filteringRegistrar{server: l.server, services: cfg.Services}
+	for _, r := range param.GRPCRegisters {
+		if err := r.Register(registrar); err != nil {
+			return nil, fmt.Errorf("fail to register grpc server: %w", err)
+		}
+	}
+	reflection.Register(registrar)
+	if err := registrar.checkServices(); err != nil {
+		return nil, err
+	}
+
+	listenFn := listen
+	if param.Listen != nil {
+		listenFn = param.Listen
+	}
+	if l.lis, err = listenFn(cfg); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// listen opens the socket of the listener. A stale unix socket file, left
+// by a server that did not stop cleanly, is removed first.
+func listen(cfg settings.Listener) (net.Listener, error) {
+	network, address := cfg.Network, cfg.Address
+	switch network {
+	case "":
+		network = settings.NetworkTCP
+	case settings.NetworkAbstract:
+		network, address = settings.NetworkUnix, "@"+address
+	case settings.NetworkUnix:
+		if info, err := os.Stat(address); err == nil && info.Mode()&os.ModeSocket != 0 {
+			if err := os.Remove(address); err != nil {
+				return nil, fmt.Errorf("fail to remove stale socket: %w", err)
+			}
+		}
+	}
+
+	lis, err := net.Listen(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("fail to start %s listener: %w", network, err)
+	}
+
+	return lis, nil
+}
+
+// filteringRegistrar registers only the services exposed by a listener.
+type filteringRegistrar struct {
+	server   *grpc.Server
+	services []string
+	offered  []string
+}
+
+var _ reflection.GRPCServer = (*filteringRegistrar)(nil)
+
+func (r *filteringRegistrar) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	r.offered = append(r.offered, desc.ServiceName)
+	if len(r.services) == 0 || slices.Contains(r.services, desc.ServiceName) {
+		r.server.RegisterService(desc, impl)
+	}
+}
+
+func (r *filteringRegistrar) GetServiceInfo() map[string]grpc.ServiceInfo {
+	return r.server.GetServiceInfo()
+}
+
+// checkServices reports the exposed services that were never registered,
+// which are likely misspelled.
+func (r *filteringRegistrar) checkServices() error {
+	var errs []error
+	for _, name := range r.services {
+		if !slices.Contains(r.offered, name) {
+			errs = append(errs, fmt.Errorf("unknown service %q", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _certificateExpiryDesc = prometheus.NewDesc(
+	"grpcbeacon_tls_certificate_expiry_timestamp_seconds",
+	"Expiry of the certificates served by the gRPC server as a unix timestamp.",
+	[]string{"listener", "subject", "serial"},
+	nil,
+)
+
+// certificateCollector reports the expiry of the certificates served by the
+// listeners. It reads the certificates from the stores so reloaded
+// certificates are reported.
+type certificateCollector struct {
+	listeners []*listener
+}
+
+var _ prometheus.Collector = (*certificateCollector)(nil)
+
+func (c *certificateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- _certificateExpiryDesc
+}
+
+func (c *certificateCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, l := range c.listeners {
+		if l.certStore == nil {
+			continue
+		}
+
+		for _, cert := range l.certStore.Certificates() {
+			ch <- prometheus.MustNewConstMetric(
+				_certificateExpiryDesc,
+				prometheus.GaugeValue,
+				float64(cert.Leaf.NotAfter.Unix()),
+				l.name,
+				cert.Leaf.Subject.String(),
+				cert.Leaf.SerialNumber.String(),
+			)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/troydai/grpcbeacon/internal/certgen"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+// _wrongServerName is the only SAN of the wrong-san certificate. The .invalid
+// TLD never resolves.
+const _wrongServerName = "wrong-name.invalid"
+
+// newMisbehavingCertificate generates the defective certificate of the
+// misbehavior mode.
+func newMisbehavingCertificate(m *settings.TLSMisbehavior, logger *zap.Logger) (*tls.Certificate, error) {
+	ca, err := misbehaviorCA(m, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := certgen.Options{
+		CommonName: "grpcbeacon " + m.Mode,
+		SANs:       m.ServerNames,
+		Usage:      certgen.UsageServer,
+	}
+	if len(opts.SANs) == 0 {
+		opts.SANs = localServerNames()
+	}
+
+	var cert *certgen.Certificate
+	switch m.Mode {
+	case settings.MisbehaviorExpired:
+		opts.NotBefore = time.Now().Add(-48 * time.Hour)
+		opts.Validity = 24 * time.Hour
+		cert, err = ca.Issue(opts)
+	case settings.MisbehaviorNotYetValid:
+		opts.NotBefore = time.Now().Add(24 * time.Hour)
+		cert, err = ca.Issue(opts)
+	case settings.MisbehaviorWrongSAN:
+		opts.SANs = []string{_wrongServerName}
+		cert, err = ca.Issue(opts)
+	case settings.MisbehaviorSelfSigned:
+		cert, err = certgen.SelfSigned(opts)
+	case settings.MisbehaviorIncompleteChain:
+		// The certificate is issued by an intermediate CA, which the server
+		// leaves out of the chain it presents.
+		var intermediate *certgen.Certificate
+		if intermediate, err = ca.IssueCA(certgen.Options{CommonName: "grpcbeacon misbehavior intermediate CA"}); err == nil {
+			if cert, err = intermediate.Issue(opts); err == nil {
+				cert.Chain = nil
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown TLS misbehavior mode %q, expected one of %s", m.Mode, strings.Join([]string{
+			settings.MisbehaviorExpired, settings.MisbehaviorNotYetValid, settings.MisbehaviorWrongSAN, settings.MisbehaviorSelfSigned, settings.MisbehaviorIncompleteChain,
+		}, ", "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fail to generate %s certificate: %w", m.Mode, err)
+	}
+
+	logger.Warn(
+		"serving misbehaving TLS certificate",
+		zap.String("mode", m.Mode),
+		zap.Strings("dns_names", cert.Cert.DNSNames),
+		zap.Time("not_before", cert.Cert.NotBefore),
+		zap.Time("not_after", cert.Cert.NotAfter),
+	)
+
+	tlsCert := cert.TLSCertificate()
+	return &tlsCert, nil
+}
+
+// misbehaviorCA loads the configured CA, or generates one. A generated CA is
+// logged so the clients under test can be told to trust it.
+func misbehaviorCA(m *settings.TLSMisbehavior, logger *zap.Logger) (*certgen.Certificate, error) {
+	if m.CACertFilePath != "" {
+		certFilePath, err := resolveFilePath(m.CACertFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("fail to resolve misbehavior CA cert file path: %w", err)
+		}
+		keyFilePath, err := resolveFilePath(m.CAKeyFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("fail to resolve misbehavior CA key file path: %w", err)
+		}
+
+		return certgen.LoadFiles(certFilePath, keyFilePath)
+	}
+
+	ca, err := certgen.NewCA(certgen.Options{CommonName: "grpcbeacon misbehavior CA"})
+	if err != nil {
+		return nil, fmt.Errorf("fail to generate misbehavior CA: %w", err)
+	}
+	logger.Info("generated misbehavior CA", zap.ByteString("ca_pem", ca.CertPEM()))
+
+	return ca, nil
+}
+
+// localServerNames are the names a local client may use to reach the
+// server.
+func localServerNames() []string {
+	names := []string{"localhost", "127.0.0.1", "::1"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "localhost" {
+		names = append(names, hostname)
+	}
+
+	return names
+}
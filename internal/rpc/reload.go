@@ -0,0 +1,305 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/troydai/grpcbeacon/internal/certgen"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+const _defaultReloadInterval = 10 * time.Second
+
+// CertStore holds the certificates and the client CA bundle served by the
+// server. It polls the files and swaps in their new content when they
+// change, so rotated certificates are served without a restart.
+type CertStore struct {
+	sources          []certSource
+	defaultIndex     int
+	clientCAFilePath string
+	interval         time.Duration
+	logger           *zap.Logger
+
+	current atomic.Pointer[tlsMaterial]
+	// lastDigest is the digest of the files last attempted, which keeps an
+	// invalid file from being reported on every poll.
+	lastDigest [sha256.Size]byte
+}
+
+type certSource struct {
+	keyFilePath  string
+	certFilePath string
+	serverNames  []string
+	// static is a certificate that is not backed by files.
+	static *tls.Certificate
+}
+
+type tlsMaterial struct {
+	certs []*tls.Certificate
+	// serverNames are the names each certificate is served for.
+	serverNames [][]string
+	clientCAs   *x509.CertPool
+}
+
+// NewCertStore loads the TLS material of the configuration. It fails if the
+// initial files are invalid.
+func NewCertStore(cfg *settings.TLSConfiguration, logger *zap.Logger) (*CertStore, error) {
+	store := &CertStore{
+		defaultIndex: -1,
+		interval:     cfg.ReloadInterval,
+		logger:       logger,
+	}
+	if store.interval == 0 {
+		store.interval = _defaultReloadInterval
+	}
+
+	if cfg.Misbehavior != nil {
+		cert, err := newMisbehavingCertificate(cfg.Misbehavior, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		store.sources = append(store.sources, certSource{static: cert})
+		store.defaultIndex = 0
+	} else if cfg.Ephemeral {
+		cert, err := newEphemeralCertificate()
+		if err != nil {
+			return nil, err
+		}
+		logger.Info(
+			"generated ephemeral TLS certificate",
+			zap.Strings("dns_names", cert.Cert.DNSNames),
+			zap.String("sha256", cert.Fingerprint()),
+		)
+
+		tlsCert := cert.TLSCertificate()
+		store.sources = append(store.sources, certSource{static: &tlsCert})
+		store.defaultIndex = 0
+	} else if cfg.KeyFilePath != "" || cfg.CertFilePath != "" || len(cfg.Certificates) == 0 {
+		if err := store.addSource(settings.CertificateConfiguration{
+			KeyFilePath:  cfg.KeyFilePath,
+			CertFilePath: cfg.CertFilePath,
+		}); err != nil {
+			return nil, err
+		}
+		store.defaultIndex = 0
+	}
+
+	explicitDefault := false
+	for _, c := range cfg.Certificates {
+		if err := store.addSource(c); err != nil {
+			return nil, err
+		}
+		if c.Default {
+			if explicitDefault {
+				return nil, errors.New("more than one default certificate")
+			}
+			explicitDefault = true
+			store.defaultIndex = len(store.sources) - 1
+		}
+	}
+	if store.defaultIndex < 0 {
+		store.defaultIndex = 0
+	}
+
+	if cfg.ClientCAFilePath != "" {
+		var err error
+		if store.clientCAFilePath, err = resolveFilePath(cfg.ClientCAFilePath); err != nil {
+			return nil, fmt.Errorf("fail to resolve client CA file path: %w", err)
+		}
+	}
+
+	if _, err := store.reload(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// newEphemeralCertificate generates a self-signed certificate for the local
+// host.
+func newEphemeralCertificate() (*certgen.Certificate, error) {
+	cert, err := certgen.SelfSigned(certgen.Options{
+		CommonName: "grpcbeacon ephemeral",
+		SANs:       localServerNames(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fail to generate ephemeral certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+func (s *CertStore) addSource(c settings.CertificateConfiguration) error {
+	keyFilePath, err := resolveFilePath(c.KeyFilePath)
+	if err != nil {
+		return fmt.Errorf("fail to resolve key file path: %w", err)
+	}
+
+	certFilePath, err := resolveFilePath(c.CertFilePath)
+	if err != nil {
+		return fmt.Errorf("fail to resolve cert file path: %w", err)
+	}
+
+	s.sources = append(s.sources, certSource{
+		keyFilePath:  keyFilePath,
+		certFilePath: certFilePath,
+		serverNames:  c.ServerNames,
+	})
+
+	return nil
+}
+
+// Certificates returns the certificates currently served.
+func (s *CertStore) Certificates() []*tls.Certificate {
+	return s.current.Load().certs
+}
+
+// CertificateFor returns the certificate served for the SNI server name. An
+// exact name takes precedence over a wildcard, and the default certificate
+// is returned when no name matches.
+func (s *CertStore) CertificateFor(serverName string) *tls.Certificate {
+	m := s.current.Load()
+
+	name := normalizeServerName(serverName)
+	wildcard := -1
+	for i, names := range m.serverNames {
+		for _, n := range names {
+			if n == name {
+				return m.certs[i]
+			}
+			if wildcard < 0 && matchWildcard(n, name) {
+				wildcard = i
+			}
+		}
+	}
+	if wildcard >= 0 {
+		return m.certs[wildcard]
+	}
+
+	return m.certs[s.defaultIndex]
+}
+
+// ClientCAs returns the client CA bundle currently used, or nil if client
+// certificates are not verified.
+func (s *CertStore) ClientCAs() *x509.CertPool {
+	return s.current.Load().clientCAs
+}
+
+// Run polls the files until ctx is done.
+func (s *CertStore) Run(ctx context.Context) {
+	if s.interval < 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.reload(); err != nil {
+				s.logger.Warn("fail to reload TLS certificate, keep serving the previous one", zap.Error(err))
+			}
+		}
+	}
+}
+
+// reload reads the files and, if they changed, swaps in their content. A
+// partially written file fails to parse, or does not match its counterpart,
+// and is rejected until the write completes. Either all the files are
+// swapped in or none.
+func (s *CertStore) reload() (bool, error) {
+	type pair struct{ keyPEM, certPEM []byte }
+
+	h := sha256.New()
+	pairs := make([]pair, len(s.sources))
+	for i, src := range s.sources {
+		if src.static != nil {
+			continue
+		}
+
+		keyPEM, err := os.ReadFile(src.keyFilePath)
+		if err != nil {
+			return false, fmt.Errorf("fail to read key file: %w", err)
+		}
+		certPEM, err := os.ReadFile(src.certFilePath)
+		if err != nil {
+			return false, fmt.Errorf("fail to read cert file: %w", err)
+		}
+		pairs[i] = pair{keyPEM: keyPEM, certPEM: certPEM}
+		h.Write(keyPEM)
+		h.Write(certPEM)
+	}
+	var caPEM []byte
+	if s.clientCAFilePath != "" {
+		var err error
+		if caPEM, err = os.ReadFile(s.clientCAFilePath); err != nil {
+			return false, fmt.Errorf("fail to read client CA file: %w", err)
+		}
+		h.Write(caPEM)
+	}
+
+	var digest [sha256.Size]byte
+	h.Sum(digest[:0])
+	if digest == s.lastDigest {
+		return false, nil
+	}
+	s.lastDigest = digest
+
+	material := &tlsMaterial{
+		certs:       make([]*tls.Certificate, len(pairs)),
+		serverNames: make([][]string, len(pairs)),
+	}
+	for i, p := range pairs {
+		if static := s.sources[i].static; static != nil {
+			material.certs[i] = static
+		} else {
+			cert, err := tls.X509KeyPair(p.certPEM, p.keyPEM)
+			if err != nil {
+				return false, fmt.Errorf("fail to create credentials from %s: %w", s.sources[i].certFilePath, err)
+			}
+			material.certs[i] = &cert
+		}
+		cert := material.certs[i]
+
+		names := s.sources[i].serverNames
+		if len(names) == 0 {
+			names = cert.Leaf.DNSNames
+		}
+		for _, n := range names {
+			material.serverNames[i] = append(material.serverNames[i], normalizeServerName(n))
+		}
+	}
+	if caPEM != nil {
+		material.clientCAs = x509.NewCertPool()
+		if !material.clientCAs.AppendCertsFromPEM(caPEM) {
+			return false, fmt.Errorf("no certificate found in CA file %s", s.clientCAFilePath)
+		}
+	}
+
+	s.current.Store(material)
+	for i, cert := range material.certs {
+		s.logger.Info(
+			"loaded TLS certificate",
+			zap.String("subject", cert.Leaf.Subject.String()),
+			zap.String("serial", cert.Leaf.SerialNumber.String()),
+			zap.Time("not_after", cert.Leaf.NotAfter),
+			zap.Strings("server_names", material.serverNames[i]),
+			zap.Bool("default", i == s.defaultIndex),
+		)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Server is the running gRPC server. It tells the addresses its listeners
+// are bound to.
+type Server struct {
+	listeners []*listener
+}
+
+// Addrs returns the bound addresses of the listeners, in the order they are
+// configured.
+func (s *Server) Addrs() []net.Addr {
+	addrs := make([]net.Addr, 0, len(s.listeners))
+	for _, l := range s.listeners {
+		addrs = append(addrs, l.lis.Addr())
+	}
+
+	return addrs
+}
+
+// Addr returns the bound address of the named listener. Listeners without a
+// name are named after their index, e.g. #0.
+func (s *Server) Addr(name string) (net.Addr, bool) {
+	for _, l := range s.listeners {
+		if l.name == name {
+			return l.lis.Addr(), true
+		}
+	}
+
+	return nil, false
+}
+
+// writeAddressFile writes an address per line. The file is renamed into
+// place so a reader never sees it partially written.
+func writeAddressFile(path string, addrs []net.Addr) error {
+	var b strings.Builder
+	for _, a := range addrs {
+		b.WriteString(a.String())
+		b.WriteByte('\n')
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("fail to create address file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	// CreateTemp makes the file readable by the owner only, while the
+	// address is for any reader.
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("fail to write address file: %w", err)
+	}
+	if _, err := tmp.WriteString(b.String()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("fail to write address file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("fail to write address file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("fail to write address file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// _servedLabel is the label of the keying material that identifies the TLS
+// session of a connection, see RFC 5705.
+const _servedLabel = "EXPORTER-grpcbeacon-served-certificate"
+
+// _served maps the keying material of every open TLS connection to the
+// certificate presented during its handshake.
+var _served sync.Map
+
+// ServedCertificateFromContext returns the certificate the server presented
+// during the handshake of the connection of the call. It returns false for
+// plaintext connections, and for TLS 1.2 connections without the extended
+// master secret, whose session cannot be identified.
+func ServedCertificateFromContext(ctx context.Context) (*x509.Certificate, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, false
+	}
+
+	key, ok := sessionKey(info.State)
+	if !ok {
+		return nil, false
+	}
+	cert, ok := _served.Load(key)
+	if !ok {
+		return nil, false
+	}
+
+	return cert.(*x509.Certificate), true
+}
+
+func sessionKey(state tls.ConnectionState) (string, bool) {
+	key, err := state.ExportKeyingMaterial(_servedLabel, nil, 32)
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// servedCredentials records the certificate selected for every connection,
+// since the server side of the TLS state does not carry it. The AuthInfo is
+// left as is for the code that expects credentials.TLSInfo.
+type servedCredentials struct {
+	credentials.TransportCredentials
+}
+
+func (c servedCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	sc := &servedConn{Conn: rawConn}
+	conn, authInfo, err := c.TransportCredentials.ServerHandshake(sc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if info, ok := authInfo.(credentials.TLSInfo); ok && sc.served != nil {
+		if key, ok := sessionKey(info.State); ok {
+			sc.key = key
+			_served.Store(key, sc.served)
+		}
+	}
+
+	return conn, authInfo, nil
+}
+
+func (c servedCredentials) Clone() credentials.TransportCredentials {
+	return servedCredentials{TransportCredentials: c.TransportCredentials.Clone()}
+}
+
+// servedConn is the connection handed to the TLS handshake, which reaches
+// GetConfigForClient through tls.ClientHelloInfo.Conn. Its certificate is
+// forgotten when it is closed.
+type servedConn struct {
+	net.Conn
+	served *x509.Certificate
+	key    string
+}
+
+func (c *servedConn) Close() error {
+	if c.key != "" {
+		_served.Delete(c.key)
+	}
+	return c.Conn.Close()
+}
+
+// recordServed keeps the certificate selected for the connection of hello.
+func recordServed(hello *tls.ClientHelloInfo, cert *tls.Certificate) {
+	if sc, ok := hello.Conn.(*servedConn); ok {
+		sc.served = cert.Leaf
+	}
+}
+
+func normalizeServerName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// matchWildcard reports whether name matches a pattern like *.example.com.
+// The wildcard stands for exactly one label.
+func matchWildcard(pattern, name string) bool {
+	suffix, ok := strings.CutPrefix(pattern, "*")
+	if !ok || !strings.HasPrefix(suffix, ".") {
+		return false
+	}
+
+	label, rest, found := strings.Cut(name, ".")
+	return found && label != "" && "."+rest == suffix
+}
@@ -1,39 +1,196 @@
 package rpc
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"os"
 	"path"
+	"slices"
 
 	"github.com/troydai/grpcbeacon/internal/settings"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
 /* facilitate the TLS */
 
-func DetermineTLSOption(cfg settings.Configuration) (grpc.ServerOption, error) {
-	if cfg.TLS == nil || !cfg.TLS.Enabled {
-		return nil, nil
+const (
+	ClientAuthNone          = "none"
+	ClientAuthRequest       = "request"
+	ClientAuthRequire       = "require"
+	ClientAuthVerifyIfGiven = "verify-if-given"
+)
+
+// DetermineTLSOption returns the credentials of a listener, and the store
+// that reloads its certificates, when TLS is enabled.
+func DetermineTLSOption(cfg *settings.TLSConfiguration, logger *zap.Logger) (grpc.ServerOption, *CertStore, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil, nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
 	}
 
-	keyFilepath, err := resolveFilePath(cfg.TLS.KeyFilePath)
+	store, err := NewCertStore(cfg, logger)
 	if err != nil {
-		return nil, fmt.Errorf("fail to resolve key file path: %w", err)
+		return nil, nil, err
 	}
 
-	certFilePath, err := resolveFilePath(cfg.TLS.CertFilePath)
+	tlsConfig, err := newTLSConfig(cfg, store)
 	if err != nil {
-		return nil, fmt.Errorf("fail to resolve cert file path: %w", err)
+		return nil, nil, err
+	}
+
+	return grpc.Creds(servedCredentials{credentials.NewTLS(tlsConfig)}), store, nil
+}
+
+// newTLSConfig returns a config that picks up the certificate for the SNI
+// and the client CA bundle from the store on every handshake. The selected
+// certificate is recorded for the connection.
+func newTLSConfig(cfg *settings.TLSConfiguration, store *CertStore) (*tls.Config, error) {
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2"},
+	}
+
+	if err := configurePolicy(base, cfg); err != nil {
+		return nil, err
+	}
+	if err := configureClientAuth(base, cfg); err != nil {
+		return nil, err
 	}
 
-	cred, err := credentials.NewServerTLSFromFile(certFilePath, keyFilepath)
+	return &tls.Config{
+		MinVersion: base.MinVersion,
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			cert := store.CertificateFor(hello.ServerName)
+			recordServed(hello, cert)
+
+			c := base.Clone()
+			c.Certificates = []tls.Certificate{*cert}
+			c.ClientCAs = store.ClientCAs()
+			return c, nil
+		},
+	}, nil
+}
+
+// configurePolicy applies the versions, cipher suites, curves, session tickets
+// and ALPN protocols of the configuration. h2 is always offered through ALPN
+// since gRPC cannot be served without it.
+func configurePolicy(tlsConfig *tls.Config, cfg *settings.TLSConfiguration) error {
+	minVersion, err := settings.ParseTLSVersion(cfg.MinVersion)
 	if err != nil {
-		return nil, fmt.Errorf("fail to create credentials: %w", err)
+		return fmt.Errorf("fail to parse the min version: %w", err)
+	}
+	if minVersion != 0 {
+		tlsConfig.MinVersion = minVersion
+	}
+	if tlsConfig.MaxVersion, err = settings.ParseTLSVersion(cfg.MaxVersion); err != nil {
+		return fmt.Errorf("fail to parse the max version: %w", err)
+	}
+	if tlsConfig.CipherSuites, err = settings.ParseCipherSuites(cfg.CipherSuites); err != nil {
+		return fmt.Errorf("fail to parse the cipher suites: %w", err)
+	}
+	if tlsConfig.CurvePreferences, err = settings.ParseCurves(cfg.CurvePreferences); err != nil {
+		return fmt.Errorf("fail to parse the curve preferences: %w", err)
+	}
+	tlsConfig.SessionTicketsDisabled = cfg.SessionTicketsDisabled
+
+	if len(cfg.ALPNProtocols) > 0 {
+		tlsConfig.NextProtos = append([]string{}, cfg.ALPNProtocols...)
+		if !slices.Contains(tlsConfig.NextProtos, "h2") {
+			tlsConfig.NextProtos = append(tlsConfig.NextProtos, "h2")
+		}
+	}
+
+	return nil
+}
+
+// configureClientAuth sets up the verification of client certificates.
+func configureClientAuth(tlsConfig *tls.Config, cfg *settings.TLSConfiguration) error {
+	mode := cfg.ClientAuth
+	if mode == "" {
+		mode = ClientAuthNone
+		if cfg.ClientCAFilePath != "" {
+			mode = ClientAuthRequire
+		}
+	}
+
+	switch mode {
+	case ClientAuthNone:
+		tlsConfig.ClientAuth = tls.NoClientCert
+	case ClientAuthRequest:
+		tlsConfig.ClientAuth = tls.RequestClientCert
+	case ClientAuthRequire:
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	case ClientAuthVerifyIfGiven:
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	default:
+		return fmt.Errorf("unknown client auth mode %q", mode)
+	}
+
+	verifies := mode == ClientAuthRequire || mode == ClientAuthVerifyIfGiven
+	allowlist := newIdentityAllowlist(cfg)
+	if allowlist != nil && !verifies {
+		return fmt.Errorf("client identity allowlists require client auth mode %s or %s", ClientAuthRequire, ClientAuthVerifyIfGiven)
+	}
+
+	if !verifies {
+		return nil
+	}
+
+	if cfg.ClientCAFilePath == "" {
+		return fmt.Errorf("client auth mode %s requires a client CA file", mode)
+	}
+
+	if allowlist != nil {
+		tlsConfig.VerifyConnection = allowlist.verifyConnection
+	}
+
+	return nil
+}
+
+// identityAllowlist admits the verified client certificates whose common
+// name, SAN or SPIFFE ID matches one of its patterns.
+type identityAllowlist struct {
+	commonNames []string
+	sans        []string
+	spiffeIDs   []string
+}
+
+func newIdentityAllowlist(cfg *settings.TLSConfiguration) *identityAllowlist {
+	if len(cfg.AllowedCommonNames) == 0 && len(cfg.AllowedSANs) == 0 && len(cfg.AllowedSPIFFEIDs) == 0 {
+		return nil
+	}
+
+	return &identityAllowlist{
+		commonNames: cfg.AllowedCommonNames,
+		sans:        cfg.AllowedSANs,
+		spiffeIDs:   cfg.AllowedSPIFFEIDs,
+	}
+}
+
+func (a *identityAllowlist) verifyConnection(state tls.ConnectionState) error {
+	// Without a certificate there is nothing to check. Whether a certificate
+	// is required is decided by the client auth mode.
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	id := newPeerIdentity(state.PeerCertificates[0])
+	if settings.MatchAny(a.commonNames, id.CommonName) || settings.MatchAny(a.spiffeIDs, id.SPIFFEID) {
+		return nil
+	}
+	for _, san := range id.SANs {
+		if settings.MatchAny(a.sans, san) {
+			return nil
+		}
 	}
 
-	return grpc.Creds(cred), nil
+	return fmt.Errorf("client certificate %q is not allowed", id.Subject)
 }
 
 func resolveFilePath(filepath string) (string, error) {
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	env "github.com/caarlos0/env/v11"
@@ -24,25 +25,173 @@ const _defaultConfigPath = "/etc/beacon-svc/beacon.toml"
 
 type (
 	Environment struct {
-		HostName string `env:"HOSTNAME"`
+		HostName   string `env:"HOSTNAME"`
+		AdminToken string `env:"BEACON_ADMIN_TOKEN"`
 	}
 
 	Configuration struct {
-		Name    string            `toml:"name"`
-		Address string            `toml:"address"`
-		Port    int               `toml:"port"`
-		Logging *Logging          `toml:"logging"`
-		TLS     *TLSConfiguration `toml:"tls"`
+		Name    string `toml:"name"`
+		Address string `toml:"address"`
+		// Port 0 binds to a free port.
+		Port         int               `toml:"port"`
+		Logging      *Logging          `toml:"logging"`
+		TLS          *TLSConfiguration `toml:"tls"`
+		Admin        *Admin            `toml:"admin"`
+		Shutdown     *Shutdown         `toml:"shutdown"`
+		Metrics      *Metrics          `toml:"metrics"`
+		Tracing      *Tracing          `toml:"tracing"`
+		Interceptors *Interceptors     `toml:"interceptors"`
+		Listeners    []Listener        `toml:"listeners"`
+		// AddressFile receives the bound address of every listener, one per
+		// line, once the server is serving.
+		AddressFile string `toml:"address_file"`
+	}
+
+	// Listener is an address the server accepts connections on. Network is
+	// tcp (the default), tcp4, tcp6, unix or abstract, a Linux abstract unix
+	// socket named by Address. Services are the full names of the services
+	// exposed, e.g. grpc.health.v1.Health, and all services are exposed when
+	// it is empty.
+	Listener struct {
+		Name     string
+		Network  string
+		Address  string
+		TLS      *TLSConfiguration
+		Services []string
 	}
 
 	Logging struct {
 		Development bool
+		Redaction   *Redaction
+	}
+
+	// Redaction controls how request metadata is redacted before it is
+	// logged. Deny adds headers to the default denylist. When Allow is set,
+	// only the listed headers are logged as is. A trailing * matches a
+	// prefix. Mode is drop, the default, or hash to log an HMAC-SHA256 digest
+	// of the values instead of dropping them. The digests are keyed with
+	// HashKey, so that they correlate across processes, or else with a key
+	// generated by the process.
+	Redaction struct {
+		Mode    string
+		Allow   []string
+		Deny    []string
+		HashKey string
+	}
+
+	// Admin enables the AdminService. Token is the bearer token callers must
+	// present. The BEACON_ADMIN_TOKEN environment variable takes precedence.
+	Admin struct {
+		Enabled bool
+		Token   string
+	}
+
+	// Shutdown controls how the server stops. During DrainPeriod every
+	// service reports NOT_SERVING while the server keeps accepting calls, so
+	// that load balancers deregister it before the connections are closed.
+	Shutdown struct {
+		DrainPeriod time.Duration
+	}
+
+	// Metrics serves the Prometheus metrics over HTTP on Address:Port at
+	// Path, which defaults to /metrics. Port 0 binds to a free port.
+	Metrics struct {
+		Enabled bool
+		Address string
+		Port    int
+		Path    string
+	}
+
+	// Tracing exports a span for every RPC. Exporter is one of otlp-grpc,
+	// otlp-http, stdout and file. Endpoint is the host:port of the OTLP
+	// collector and FilePath the output of the file exporter. SampleRatio
+	// is the fraction of new traces that are sampled, all of them when zero.
+	Tracing struct {
+		Enabled     bool
+		Exporter    string
+		Endpoint    string
+		Insecure    bool
+		FilePath    string
+		SampleRatio float64
+		ServiceName string
+	}
+
+	// Interceptors lists the server interceptors by name in the order they
+	// run, the first one being the outermost. The known names are recovery
+	// and accesslog. Only recovery runs when the list is empty.
+	Interceptors struct {
+		Chain     []string
+		AccessLog *AccessLog
 	}
 
+	// AccessLog samples the successful calls that are logged. SampleRate is
+	// a fraction in [0, 1]. Every call is logged when it is unset, and none
+	// of the successful ones when it is zero. Failed calls are always logged.
+	// Metadata adds the redacted request metadata.
+	AccessLog struct {
+		SampleRate *float64
+		Metadata   bool
+	}
+
+	// TLSConfiguration enables TLS, and mutual TLS when ClientCAFilePath is
+	// set. ClientAuth is one of none, request, require and verify-if-given. It
+	// defaults to require when a client CA bundle is configured and to none
+	// otherwise. A verified client certificate must match one of the allowed
+	// common names, SANs or SPIFFE IDs when any is configured. A trailing *
+	// matches a prefix. The files are checked for changes every
+	// ReloadInterval, 10s by default, and a negative interval disables the
+	// reload.
 	TLSConfiguration struct {
 		Enabled      bool
 		KeyFilePath  string
 		CertFilePath string
+		// Ephemeral serves a self-signed certificate generated in memory at
+		// startup in place of KeyFilePath and CertFilePath.
+		Ephemeral bool
+		// Misbehavior serves a defective certificate to test how clients
+		// validate the server.
+		Misbehavior        *TLSMisbehavior
+		Certificates       []CertificateConfiguration
+		ReloadInterval     time.Duration
+		ClientCAFilePath   string
+		ClientAuth         string
+		AllowedCommonNames []string
+		AllowedSANs        []string
+		AllowedSPIFFEIDs   []string
+
+		// The handshake policy. Validate lists the accepted names.
+		MinVersion             string
+		MaxVersion             string
+		CipherSuites           []string
+		CurvePreferences       []string
+		SessionTicketsDisabled bool
+		ALPNProtocols          []string
+	}
+
+	// TLSMisbehavior replaces the served certificates with a defective one
+	// generated at startup. Mode is one of expired, not-yet-valid,
+	// wrong-san, self-signed and incomplete-chain. Except for self-signed,
+	// the certificate is issued by the CA of CACertFilePath and
+	// CAKeyFilePath, which the clients under test should trust, or by a CA
+	// generated on the fly. ServerNames default to the local host names.
+	TLSMisbehavior struct {
+		Mode           string
+		CACertFilePath string
+		CAKeyFilePath  string
+		ServerNames    []string
+	}
+
+	// CertificateConfiguration is a certificate served to the clients whose
+	// SNI matches one of ServerNames, which default to the DNS SANs of the
+	// certificate. A name like *.example.com matches one label. The Default
+	// certificate is served when no name matches. Without one, the top-level
+	// KeyFilePath and CertFilePath pair, or else the first certificate, is
+	// the default.
+	CertificateConfiguration struct {
+		KeyFilePath  string
+		CertFilePath string
+		ServerNames  []string
+		Default      bool
 	}
 )
 
@@ -82,20 +231,30 @@ func LoadConfig() (Configuration, error) {
 	if _, err := os.Stat(configFlag); err != nil {
 		if os.IsNotExist(err) {
 			logger.Info("fall back to default config")
-			return defaultConfig(), nil
+			return DefaultConfig(), nil
 		}
 		return Configuration{}, fmt.Errorf("fail to stat config file: %w", err)
 	}
 
+	return ReadConfigFile(configFlag)
+}
+
+// ReadConfigFile decodes and validates the TOML config file at path.
+func ReadConfigFile(path string) (Configuration, error) {
 	var config Configuration
-	if _, err := toml.DecodeFile(configFlag, &config); err != nil {
+	if _, err := toml.DecodeFile(path, &config); err != nil {
 		return Configuration{}, fmt.Errorf("fail to decode config file: %w", err)
 	}
 
+	if err := config.Validate(); err != nil {
+		return Configuration{}, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
 	return config, nil
 }
 
-func defaultConfig() Configuration {
+// DefaultConfig is the configuration used when there is no config file.
+func DefaultConfig() Configuration {
 	return Configuration{
 		Name:    "red cliff",
 		Address: "127.0.0.1",
@@ -2,6 +2,7 @@ package settings_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/stretchr/testify/assert"
@@ -13,14 +14,79 @@ const _testSample1 = `
 name = "white peak"
 address = "127.0.0.1"
 port = 6899
+address_file = "/run/beacon/address"
 
 [logging]
 Development = true
 
+[logging.redaction]
+Mode = "hash"
+Allow = ["x-request-id"]
+Deny = ["x-tenant-*"]
+
 [tls]
 Enabled = true
 KeyFilePath = "/path/to/key"
 CertFilePath = "/path/to/cert"
+ReloadInterval = "30s"
+ClientCAFilePath = "/path/to/ca"
+ClientAuth = "verify-if-given"
+AllowedSPIFFEIDs = ["spiffe://example.org/*"]
+MinVersion = "1.2"
+MaxVersion = "1.3"
+CipherSuites = ["TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"]
+CurvePreferences = ["X25519"]
+SessionTicketsDisabled = true
+ALPNProtocols = ["h2"]
+
+[[tls.Certificates]]
+KeyFilePath = "/path/to/alpha.key"
+CertFilePath = "/path/to/alpha.crt"
+ServerNames = ["alpha.example.org", "*.alpha.example.org"]
+
+[[tls.Certificates]]
+KeyFilePath = "/path/to/beta.key"
+CertFilePath = "/path/to/beta.crt"
+Default = true
+
+[admin]
+Enabled = true
+Token = "secret"
+
+[shutdown]
+DrainPeriod = "5s"
+
+[metrics]
+Enabled = true
+Address = "0.0.0.0"
+Port = 9090
+
+[tracing]
+Enabled = true
+Exporter = "otlp-grpc"
+Endpoint = "localhost:4317"
+Insecure = true
+SampleRatio = 0.5
+
+[interceptors]
+Chain = ["accesslog", "recovery"]
+
+[interceptors.accesslog]
+SampleRate = 0.25
+
+[[listeners]]
+Name = "external"
+Address = "0.0.0.0:8443"
+
+[listeners.tls]
+Enabled = true
+Ephemeral = true
+
+[[listeners]]
+Name = "sidecar"
+Network = "unix"
+Address = "/var/run/beacon.sock"
+Services = ["grpc.health.v1.Health"]
 `
 
 const _testSample2 = `
@@ -39,14 +105,80 @@ func TestDataModel(t *testing.T) {
 			name:  "full configuration",
 			input: _testSample1,
 			expectation: func(t *testing.T, c settings.Configuration) {
+				assert.Equal(t, "/run/beacon/address", c.AddressFile)
 
 				require.NotNil(t, c.Logging)
 				assert.True(t, c.Logging.Development)
+				require.NotNil(t, c.Logging.Redaction)
+				assert.Equal(t, "hash", c.Logging.Redaction.Mode)
+				assert.Equal(t, []string{"x-request-id"}, c.Logging.Redaction.Allow)
+				assert.Equal(t, []string{"x-tenant-*"}, c.Logging.Redaction.Deny)
 
 				require.NotNil(t, c.TLS)
 				assert.True(t, c.TLS.Enabled)
 				assert.Equal(t, "/path/to/key", c.TLS.KeyFilePath)
 				assert.Equal(t, "/path/to/cert", c.TLS.CertFilePath)
+				assert.Equal(t, []settings.CertificateConfiguration{
+					{
+						KeyFilePath:  "/path/to/alpha.key",
+						CertFilePath: "/path/to/alpha.crt",
+						ServerNames:  []string{"alpha.example.org", "*.alpha.example.org"},
+					},
+					{
+						KeyFilePath:  "/path/to/beta.key",
+						CertFilePath: "/path/to/beta.crt",
+						Default:      true,
+					},
+				}, c.TLS.Certificates)
+				assert.Equal(t, 30*time.Second, c.TLS.ReloadInterval)
+				assert.Equal(t, "/path/to/ca", c.TLS.ClientCAFilePath)
+				assert.Equal(t, "verify-if-given", c.TLS.ClientAuth)
+				assert.Equal(t, []string{"spiffe://example.org/*"}, c.TLS.AllowedSPIFFEIDs)
+				assert.Equal(t, "1.2", c.TLS.MinVersion)
+				assert.Equal(t, "1.3", c.TLS.MaxVersion)
+				assert.Equal(t, []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}, c.TLS.CipherSuites)
+				assert.Equal(t, []string{"X25519"}, c.TLS.CurvePreferences)
+				assert.True(t, c.TLS.SessionTicketsDisabled)
+				assert.Equal(t, []string{"h2"}, c.TLS.ALPNProtocols)
+				assert.NoError(t, c.Validate())
+
+				require.NotNil(t, c.Admin)
+				assert.True(t, c.Admin.Enabled)
+				assert.Equal(t, "secret", c.Admin.Token)
+
+				require.NotNil(t, c.Shutdown)
+				assert.Equal(t, 5*time.Second, c.Shutdown.DrainPeriod)
+
+				require.NotNil(t, c.Metrics)
+				assert.True(t, c.Metrics.Enabled)
+				assert.Equal(t, "0.0.0.0", c.Metrics.Address)
+				assert.Equal(t, 9090, c.Metrics.Port)
+				assert.Empty(t, c.Metrics.Path)
+
+				require.NotNil(t, c.Tracing)
+				assert.True(t, c.Tracing.Enabled)
+				assert.Equal(t, "otlp-grpc", c.Tracing.Exporter)
+				assert.Equal(t, "localhost:4317", c.Tracing.Endpoint)
+				assert.True(t, c.Tracing.Insecure)
+				assert.Equal(t, 0.5, c.Tracing.SampleRatio)
+
+				require.NotNil(t, c.Interceptors)
+				assert.Equal(t, []string{"accesslog", "recovery"}, c.Interceptors.Chain)
+				require.NotNil(t, c.Interceptors.AccessLog)
+				assert.Equal(t, 0.25, *c.Interceptors.AccessLog.SampleRate)
+
+				require.Len(t, c.Listeners, 2)
+				assert.Equal(t, "external", c.Listeners[0].Name)
+				assert.Equal(t, "0.0.0.0:8443", c.Listeners[0].Address)
+				require.NotNil(t, c.Listeners[0].TLS)
+				assert.True(t, c.Listeners[0].TLS.Ephemeral)
+				assert.Equal(t, settings.Listener{
+					Name:     "sidecar",
+					Network:  "unix",
+					Address:  "/var/run/beacon.sock",
+					Services: []string{"grpc.health.v1.Health"},
+				}, c.Listeners[1])
+				assert.Equal(t, c.Listeners, c.ListenerConfigurations())
 			},
 		},
 		{
@@ -55,6 +187,11 @@ func TestDataModel(t *testing.T) {
 			expectation: func(t *testing.T, c settings.Configuration) {
 				assert.Nil(t, c.Logging)
 				assert.Nil(t, c.TLS)
+				assert.Nil(t, c.Admin)
+				assert.Equal(t, []settings.Listener{{
+					Network: "tcp",
+					Address: "127.0.0.1:6899",
+				}}, c.ListenerConfigurations())
 			},
 		},
 	}
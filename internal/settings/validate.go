@@ -0,0 +1,261 @@
+package settings
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	_tlsVersions = map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+
+	_curves = map[string]tls.CurveID{
+		"X25519":         tls.X25519,
+		"P-256":          tls.CurveP256,
+		"P-384":          tls.CurveP384,
+		"P-521":          tls.CurveP521,
+		"X25519MLKEM768": tls.X25519MLKEM768,
+	}
+)
+
+// The networks of a listener.
+const (
+	NetworkTCP      = "tcp"
+	NetworkTCP4     = "tcp4"
+	NetworkTCP6     = "tcp6"
+	NetworkUnix     = "unix"
+	NetworkAbstract = "abstract"
+)
+
+// The TLS misbehavior modes, each serving a certificate that a correct
+// client rejects.
+const (
+	MisbehaviorExpired         = "expired"
+	MisbehaviorNotYetValid     = "not-yet-valid"
+	MisbehaviorWrongSAN        = "wrong-san"
+	MisbehaviorSelfSigned      = "self-signed"
+	MisbehaviorIncompleteChain = "incomplete-chain"
+)
+
+// MatchAny reports whether value matches one of the patterns, where a
+// trailing * matches a prefix. An empty value matches nothing.
+func MatchAny(patterns []string, value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, p := range patterns {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(value, prefix) {
+				return true
+			}
+		} else if p == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate reports the first invalid setting of the configuration.
+func (c Configuration) Validate() error {
+	if c.TLS != nil {
+		if err := c.TLS.Validate(); err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+	}
+
+	names := map[string]bool{}
+	for i, l := range c.Listeners {
+		name := l.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		if names[name] {
+			return fmt.Errorf("listeners: duplicate name %q", name)
+		}
+		names[name] = true
+
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("listeners %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the network and TLS settings of the listener.
+func (l Listener) Validate() error {
+	switch l.Network {
+	case "", NetworkTCP, NetworkTCP4, NetworkTCP6, NetworkUnix, NetworkAbstract:
+	default:
+		return fmt.Errorf("unknown network %q, expected one of %s", l.Network, strings.Join([]string{
+			NetworkTCP, NetworkTCP4, NetworkTCP6, NetworkUnix, NetworkAbstract,
+		}, ", "))
+	}
+
+	if l.Address == "" {
+		return errors.New("address is empty")
+	}
+
+	if l.TLS != nil {
+		if err := l.TLS.Validate(); err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListenerConfigurations returns the configured listeners. Without any, it
+// returns a single TCP listener on Address and Port, secured by TLS and
+// exposing all services.
+func (c Configuration) ListenerConfigurations() []Listener {
+	if len(c.Listeners) > 0 {
+		return c.Listeners
+	}
+
+	return []Listener{{
+		Network: NetworkTCP,
+		Address: net.JoinHostPort(c.Address, strconv.Itoa(c.Port)),
+		TLS:     c.TLS,
+	}}
+}
+
+// Validate checks the certificate sources and the names of the handshake
+// policy. Versions are 1.0, 1.1, 1.2 and 1.3, and MinVersion defaults to 1.2.
+// Cipher suites use the IANA names, e.g.
+// TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, and curves are X25519, P-256,
+// P-384, P-521 and X25519MLKEM768.
+func (c *TLSConfiguration) Validate() error {
+	if c.Ephemeral && (c.KeyFilePath != "" || c.CertFilePath != "") {
+		return errors.New("ephemeral certificates cannot be combined with a key or cert file")
+	}
+	if m := c.Misbehavior; m != nil {
+		switch m.Mode {
+		case MisbehaviorExpired, MisbehaviorNotYetValid, MisbehaviorWrongSAN, MisbehaviorSelfSigned, MisbehaviorIncompleteChain:
+		default:
+			return fmt.Errorf("misbehavior: unknown mode %q, expected one of %s", m.Mode, strings.Join([]string{
+				MisbehaviorExpired, MisbehaviorNotYetValid, MisbehaviorWrongSAN, MisbehaviorSelfSigned, MisbehaviorIncompleteChain,
+			}, ", "))
+		}
+		if c.Ephemeral || c.KeyFilePath != "" || c.CertFilePath != "" || len(c.Certificates) > 0 {
+			return errors.New("misbehavior replaces the served certificates and cannot be combined with ephemeral certificates, a key or cert file, or certificates")
+		}
+		if (m.CACertFilePath == "") != (m.CAKeyFilePath == "") {
+			return errors.New("misbehavior: the CA cert and key files must be set together")
+		}
+	}
+
+	minVersion, err := ParseTLSVersion(c.MinVersion)
+	if err != nil {
+		return fmt.Errorf("min version: %w", err)
+	}
+	maxVersion, err := ParseTLSVersion(c.MaxVersion)
+	if err != nil {
+		return fmt.Errorf("max version: %w", err)
+	}
+	if maxVersion != 0 {
+		minName := c.MinVersion
+		if minVersion == 0 {
+			minVersion, minName = tls.VersionTLS12, "1.2 (the default)"
+		}
+		if minVersion > maxVersion {
+			return fmt.Errorf("max version %s is below min version %s", c.MaxVersion, minName)
+		}
+	}
+
+	if _, err := ParseCipherSuites(c.CipherSuites); err != nil {
+		return fmt.Errorf("cipher suites: %w", err)
+	}
+	if _, err := ParseCurves(c.CurvePreferences); err != nil {
+		return fmt.Errorf("curve preferences: %w", err)
+	}
+
+	for _, p := range c.ALPNProtocols {
+		if p == "" || len(p) > 255 {
+			return fmt.Errorf("alpn protocols: invalid protocol %q", p)
+		}
+	}
+
+	return nil
+}
+
+// ParseTLSVersion returns the version of a name like 1.2. An empty name
+// returns 0, which leaves the default of crypto/tls.
+func ParseTLSVersion(name string) (uint16, error) {
+	if name == "" {
+		return 0, nil
+	}
+
+	v, ok := _tlsVersions[strings.TrimPrefix(strings.ToUpper(strings.ReplaceAll(name, " ", "")), "TLS")]
+	if !ok {
+		return 0, fmt.Errorf("unknown TLS version %q, expected one of %s", name, strings.Join(sortedKeys(_tlsVersions), ", "))
+	}
+
+	return v, nil
+}
+
+// ParseCipherSuites returns the IDs of the named cipher suites, including
+// the insecure ones crypto/tls implements.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	known := map[string]uint16{}
+	for _, cs := range tls.CipherSuites() {
+		known[cs.Name] = cs.ID
+	}
+	for _, cs := range tls.InsecureCipherSuites() {
+		known[cs.Name] = cs.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, n := range names {
+		id, ok := known[n]
+		if !ok {
+			return nil, fmt.Errorf("unknown cipher suite %q, expected one of %s", n, strings.Join(sortedKeys(known), ", "))
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// ParseCurves returns the IDs of the named curves.
+func ParseCurves(names []string) ([]tls.CurveID, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]tls.CurveID, 0, len(names))
+	for _, n := range names {
+		id, ok := _curves[n]
+		if !ok {
+			return nil, fmt.Errorf("unknown curve %q, expected one of %s", n, strings.Join(sortedKeys(_curves), ", "))
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,145 @@
+package settings_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+func TestTLSConfigurationValidate(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    settings.TLSConfiguration
+		errorMsg string
+	}{
+		{
+			name:  "empty",
+			input: settings.TLSConfiguration{},
+		},
+		{
+			name: "valid policy",
+			input: settings.TLSConfiguration{
+				MinVersion:       "1.2",
+				MaxVersion:       "TLS 1.3",
+				CipherSuites:     []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_RSA_WITH_AES_128_CBC_SHA"},
+				CurvePreferences: []string{"X25519", "P-256"},
+				ALPNProtocols:    []string{"h2", "beacon/1"},
+			},
+		},
+		{
+			name:  "ephemeral",
+			input: settings.TLSConfiguration{Ephemeral: true},
+		},
+		{
+			name:     "ephemeral with files",
+			input:    settings.TLSConfiguration{Ephemeral: true, CertFilePath: "/path/to/cert"},
+			errorMsg: "ephemeral certificates cannot be combined with a key or cert file",
+		},
+		{
+			name:  "misbehavior",
+			input: settings.TLSConfiguration{Misbehavior: &settings.TLSMisbehavior{Mode: "expired"}},
+		},
+		{
+			name: "misbehavior with files",
+			input: settings.TLSConfiguration{
+				KeyFilePath: "/path/to/key",
+				Misbehavior: &settings.TLSMisbehavior{Mode: "expired"},
+			},
+			errorMsg: "misbehavior replaces the served certificates",
+		},
+		{
+			name:     "unknown misbehavior mode",
+			input:    settings.TLSConfiguration{Misbehavior: &settings.TLSMisbehavior{Mode: "revoked"}},
+			errorMsg: `misbehavior: unknown mode "revoked", expected one of expired, not-yet-valid, wrong-san, self-signed, incomplete-chain`,
+		},
+		{
+			name:     "misbehavior with partial CA",
+			input:    settings.TLSConfiguration{Misbehavior: &settings.TLSMisbehavior{Mode: "expired", CACertFilePath: "/path/to/ca"}},
+			errorMsg: "misbehavior: the CA cert and key files must be set together",
+		},
+		{
+			name:     "unknown version",
+			input:    settings.TLSConfiguration{MinVersion: "1.4"},
+			errorMsg: `min version: unknown TLS version "1.4", expected one of 1.0, 1.1, 1.2, 1.3`,
+		},
+		{
+			name:     "inverted versions",
+			input:    settings.TLSConfiguration{MinVersion: "1.3", MaxVersion: "1.2"},
+			errorMsg: "max version 1.2 is below min version 1.3",
+		},
+		{
+			name:     "max version below default min version",
+			input:    settings.TLSConfiguration{MaxVersion: "1.1"},
+			errorMsg: "max version 1.1 is below min version 1.2 (the default)",
+		},
+		{
+			name:     "unknown cipher suite",
+			input:    settings.TLSConfiguration{CipherSuites: []string{"TLS_FAKE"}},
+			errorMsg: `cipher suites: unknown cipher suite "TLS_FAKE"`,
+		},
+		{
+			name:     "unknown curve",
+			input:    settings.TLSConfiguration{CurvePreferences: []string{"P-224"}},
+			errorMsg: `curve preferences: unknown curve "P-224", expected one of P-256, P-384, P-521, X25519, X25519MLKEM768`,
+		},
+		{
+			name:     "empty ALPN protocol",
+			input:    settings.TLSConfiguration{ALPNProtocols: []string{""}},
+			errorMsg: `alpn protocols: invalid protocol ""`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+			if tc.errorMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorMsg)
+		})
+	}
+}
+
+func TestConfigurationValidate(t *testing.T) {
+	c := settings.Configuration{TLS: &settings.TLSConfiguration{MinVersion: "ssl3"}}
+	assert.EqualError(t, c.Validate(), `tls: min version: unknown TLS version "ssl3", expected one of 1.0, 1.1, 1.2, 1.3`)
+
+	assert.NoError(t, settings.Configuration{}.Validate())
+
+	c = settings.Configuration{Listeners: []settings.Listener{{Network: "sctp", Address: "127.0.0.1:0"}}}
+	assert.EqualError(t, c.Validate(), `listeners #0: unknown network "sctp", expected one of tcp, tcp4, tcp6, unix, abstract`)
+
+	c = settings.Configuration{Listeners: []settings.Listener{{Name: "uds", Network: "unix"}}}
+	assert.EqualError(t, c.Validate(), "listeners uds: address is empty")
+
+	c = settings.Configuration{Listeners: []settings.Listener{
+		{Name: "a", Address: "127.0.0.1:0"},
+		{Name: "a", Address: "127.0.0.1:1"},
+	}}
+	assert.EqualError(t, c.Validate(), `listeners: duplicate name "a"`)
+
+	c = settings.Configuration{Listeners: []settings.Listener{
+		{Address: "127.0.0.1:0", TLS: &settings.TLSConfiguration{CurvePreferences: []string{"P-1"}}},
+	}}
+	assert.ErrorContains(t, c.Validate(), `listeners #0: tls: curve preferences: unknown curve "P-1"`)
+}
+
+func TestParsePolicy(t *testing.T) {
+	v, err := settings.ParseTLSVersion("tls1.3")
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), v)
+
+	suites, err := settings.ParseCipherSuites([]string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"})
+	require.NoError(t, err)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256}, suites)
+
+	curves, err := settings.ParseCurves([]string{"P-384", "X25519"})
+	require.NoError(t, err)
+	assert.Equal(t, []tls.CurveID{tls.CurveP384, tls.X25519}, curves)
+}
@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+var Module = fx.Provide(ProvideTracing)
+
+const (
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+	ExporterStdout   = "stdout"
+	ExporterFile     = "file"
+
+	_defaultServiceName = "grpcbeacon"
+)
+
+type (
+	Param struct {
+		fx.In
+
+		Lifecycle fx.Lifecycle
+		Logger    *zap.Logger
+		Config    settings.Configuration
+		Env       settings.Environment
+	}
+
+	Result struct {
+		fx.Out
+
+		ServerOptions []grpc.ServerOption `group:"grpc_server_options,flatten"`
+	}
+)
+
+// ProvideTracing installs a stats handler that extracts the W3C trace context
+// and baggage from the incoming metadata and records a span for every RPC.
+func ProvideTracing(param Param) (Result, error) {
+	cfg := param.Config.Tracing
+	if cfg == nil || !cfg.Enabled {
+		return Result{}, nil
+	}
+
+	exporter, closer, err := newExporter(cfg)
+	if err != nil {
+		return Result{}, fmt.Errorf("fail to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = _defaultServiceName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.instance.id", param.Config.Name),
+		attribute.String("host.name", param.Env.HostName),
+	))
+	if err != nil {
+		return Result{}, fmt.Errorf("fail to create trace resource: %w", err)
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+
+	param.Lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			if err := provider.Shutdown(ctx); err != nil {
+				return fmt.Errorf("fail to shutdown tracer provider: %w", err)
+			}
+			if closer != nil {
+				return closer.Close()
+			}
+			return nil
+		},
+	})
+
+	param.Logger.Info("tracing enabled", zap.String("exporter", cfg.Exporter), zap.Float64("sampleRatio", ratio))
+
+	handler := otelgrpc.NewServerHandler(
+		otelgrpc.WithTracerProvider(provider),
+		otelgrpc.WithPropagators(propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		)),
+	)
+
+	return Result{ServerOptions: []grpc.ServerOption{grpc.StatsHandler(handler)}}, nil
+}
+
+// newExporter returns the span exporter and, for the file exporter, the file
+// to close once the exporter is shut down.
+func newExporter(cfg *settings.Tracing) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case ExporterOTLPGRPC:
+		opts := []otlptracegrpc.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(context.Background(), opts...)
+		return exporter, nil, err
+	case ExporterOTLPHTTP:
+		opts := []otlptracehttp.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(context.Background(), opts...)
+		return exporter, nil, err
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exporter, nil, err
+	case ExporterFile:
+		if cfg.FilePath == "" {
+			return nil, nil, fmt.Errorf("file path is empty")
+		}
+		f, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fail to open trace file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			return nil, nil, errors.Join(err, f.Close())
+		}
+		return exporter, f, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown exporter %q", cfg.Exporter)
+}
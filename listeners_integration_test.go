@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+func TestIntegration_MultipleListeners(t *testing.T) {
+	dir := t.TempDir()
+	socketPath := filepath.Join(dir, "beacon.sock")
+	addressFile := filepath.Join(dir, "beacon.addr")
+	abstractName := fmt.Sprintf("grpcbeacon-test-%d", time.Now().UnixNano())
+
+	testConfig := settings.Configuration{
+		Name:        "test-beacon",
+		AddressFile: addressFile,
+		Listeners: []settings.Listener{
+			{
+				Name:    "external",
+				Network: settings.NetworkTCP,
+				Address: "127.0.0.1:0",
+				TLS:     &settings.TLSConfiguration{Enabled: true, Ephemeral: true},
+			},
+			{
+				Name:     "sidecar",
+				Network:  settings.NetworkUnix,
+				Address:  socketPath,
+				Services: []string{"troydai.grpcbeacon.v1.BeaconService", "grpc.health.v1.Health"},
+			},
+			{
+				Name:     "probe",
+				Network:  settings.NetworkAbstract,
+				Address:  abstractName,
+				Services: []string{"grpc.health.v1.Health"},
+			},
+		},
+	}
+
+	var server *rpc.Server
+	app := fxtest.New(t,
+		fx.Populate(&server),
+		fx.Provide(func() settings.Configuration { return testConfig }),
+		fx.Provide(func() settings.Environment { return settings.Environment{HostName: "test-host"} }),
+		logging.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+
+	startCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Start(startCtx))
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, app.Stop(stopCtx))
+
+		_, err := os.Stat(addressFile)
+		assert.True(t, os.IsNotExist(err), "address file is removed on stop")
+	}()
+
+	external, ok := server.Addr("external")
+	require.True(t, ok)
+	assert.NotZero(t, external.(*net.TCPAddr).Port)
+
+	t.Run("address file lists the bound addresses", func(t *testing.T) {
+		content, err := os.ReadFile(addressFile)
+		require.NoError(t, err)
+		assert.Equal(t, external.String()+"\n"+socketPath+"\n@"+abstractName+"\n", string(content))
+
+		stat, err := os.Stat(addressFile)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o644), stat.Mode().Perm(), "address file is readable by everyone")
+	})
+
+	dial := func(t *testing.T, target string, creds credentials.TransportCredentials) *grpc.ClientConn {
+		conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, conn.Close()) })
+		return conn
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("TLS over TCP exposes all services", func(t *testing.T) {
+		conn := dial(t, external.String(), credentials.NewTLS(&tls.Config{InsecureSkipVerify: true}))
+
+		resp, err := pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{Message: "hello"})
+		require.NoError(t, err)
+		assert.NotNil(t, resp.GetRequest().GetPeer().GetTls())
+
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("plaintext over unix socket", func(t *testing.T) {
+		conn := dial(t, "unix://"+socketPath, insecure.NewCredentials())
+
+		resp, err := pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{Message: "hello"})
+		require.NoError(t, err)
+		assert.Equal(t, "test-beacon", resp.GetDetails()["BeaconName"])
+		assert.Nil(t, resp.GetRequest().GetPeer().GetTls())
+	})
+
+	t.Run("abstract socket only exposes health", func(t *testing.T) {
+		conn := dial(t, "unix-abstract:"+abstractName, insecure.NewCredentials())
+
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+		_, err = pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{Message: "hello"})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+func TestIntegration_ListenerUnknownService(t *testing.T) {
+	testConfig := settings.Configuration{
+		Name: "test-beacon",
+		Listeners: []settings.Listener{{
+			Network:  settings.NetworkUnix,
+			Address:  filepath.Join(t.TempDir(), "beacon.sock"),
+			Services: []string{"troydai.grpcbeacon.v1.BeaconServce"},
+		}},
+	}
+
+	app := fx.New(
+		fx.NopLogger,
+		fx.Provide(func() settings.Configuration { return testConfig }),
+		fx.Provide(func() settings.Environment { return settings.Environment{HostName: "test-host"} }),
+		logging.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+
+	require.Error(t, app.Err())
+	assert.Contains(t, app.Err().Error(), `unknown service "troydai.grpcbeacon.v1.BeaconServce"`)
+}
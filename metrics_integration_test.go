@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/metrics"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+func TestIntegration_Metrics(t *testing.T) {
+	testConfig := settings.Configuration{
+		Name:    "metrics-beacon",
+		Address: "127.0.0.1",
+		Metrics: &settings.Metrics{
+			Enabled: true,
+			Address: "127.0.0.1",
+		},
+	}
+
+	var (
+		server        *rpc.Server
+		metricsServer *metrics.Server
+	)
+	app := fxtest.New(t,
+		fx.Populate(&server, &metricsServer),
+		fx.Provide(func() settings.Configuration { return testConfig }),
+		fx.Provide(func() settings.Environment { return settings.Environment{HostName: "metrics-host"} }),
+		logging.Module,
+		metrics.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+
+	startCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Start(startCtx))
+
+	port := server.Addrs()[0].(*net.TCPAddr).Port
+	metricsPort := metricsServer.Addr().(*net.TCPAddr).Port
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("127.0.0.1:%d", port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+
+	client := pb.NewBeaconServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = client.Signal(ctx, &pb.SignalRequest{})
+	require.NoError(t, err)
+	_, err = client.Signal(metadata.AppendToOutgoingContext(ctx, beacon.MetadataStatusCode, "UNAVAILABLE"), &pb.SignalRequest{})
+	require.Error(t, err)
+
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", metricsPort))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, resp.Body.Close()) }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	text := string(body)
+
+	assert.Contains(t, text, `grpc_server_handled_total{grpc_code="OK",grpc_method="Signal",grpc_service="troydai.grpcbeacon.v1.BeaconService",grpc_type="unary"} 1`)
+	assert.Contains(t, text, `grpc_server_handled_total{grpc_code="Unavailable",grpc_method="Signal",grpc_service="troydai.grpcbeacon.v1.BeaconService",grpc_type="unary"} 1`)
+	assert.Contains(t, text, `grpc_server_handling_seconds_count{grpc_method="Signal",grpc_service="troydai.grpcbeacon.v1.BeaconService",grpc_type="unary"} 2`)
+	assert.Contains(t, text, `grpc_server_in_flight{grpc_method="Signal",grpc_service="troydai.grpcbeacon.v1.BeaconService",grpc_type="unary"} 0`)
+	assert.Contains(t, text, `grpcbeacon_health_status{service="troydai.grpcbeacon.v1.BeaconService",status="SERVING"} 1`)
+	assert.Contains(t, text, `grpcbeacon_health_status{service="troydai.grpcbeacon.v1.BeaconService",status="NOT_SERVING"} 0`)
+	assert.Contains(t, text, "go_goroutines")
+	assert.Contains(t, text, "process_cpu_seconds_total")
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Stop(stopCtx))
+}
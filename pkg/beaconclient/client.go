@@ -0,0 +1,195 @@
+// Package beaconclient calls grpcbeacon servers. It dials the beacon with
+// the usual production settings, TLS, keepalive, retries or hedging, load
+// balancing and health checking, and wraps the beacon calls in typed
+// helpers, so other tools can embed beacon probes.
+package beaconclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/health" // enables the client side health checking
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+)
+
+// Client calls a beacon.
+type Client struct {
+	conn   *grpc.ClientConn
+	beacon pb.BeaconServiceClient
+	health healthpb.HealthClient
+	admin  pb.AdminServiceClient
+}
+
+// Dial creates a client of the beacon at target, any gRPC target such as
+// dns:///beacon:8080 or unix:///run/beacon.sock. The connection is made on
+// the first call.
+func Dial(target string, opts ...Option) (*Client, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dialOptions, err := o.grpcOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(target, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create client for %s: %w", target, err)
+	}
+
+	return NewClient(conn), nil
+}
+
+// NewClient wraps an existing connection.
+func NewClient(conn *grpc.ClientConn) *Client {
+	return &Client{
+		conn:   conn,
+		beacon: pb.NewBeaconServiceClient(conn),
+		health: healthpb.NewHealthClient(conn),
+		admin:  pb.NewAdminServiceClient(conn),
+	}
+}
+
+// Conn returns the underlying connection.
+func (c *Client) Conn() *grpc.ClientConn { return c.conn }
+
+// Beacon returns the generated client of the BeaconService.
+func (c *Client) Beacon() pb.BeaconServiceClient { return c.beacon }
+
+// Health returns the generated client of the health service.
+func (c *Client) Health() healthpb.HealthClient { return c.health }
+
+// Admin returns the generated client of the AdminService. Its calls need an
+// "authorization: Bearer <token>" header.
+func (c *Client) Admin() pb.AdminServiceClient { return c.admin }
+
+// Close closes the connection.
+func (c *Client) Close() error { return c.conn.Close() }
+
+// SignalOption adds to the request of Signal and SignalStream.
+type SignalOption func(*pb.SignalRequest)
+
+// Message sets the message of the request.
+func Message(m string) SignalOption {
+	return func(r *pb.SignalRequest) { r.Message = m }
+}
+
+// Delay has the beacon wait before answering.
+func Delay(d time.Duration) SignalOption {
+	return func(r *pb.SignalRequest) { fault(r).Delay = durationpb.New(d) }
+}
+
+// FailWith has the beacon fail with code, with the probability in [0, 1]. A
+// zero probability never fails.
+func FailWith(code codes.Code, probability float64) SignalOption {
+	return func(r *pb.SignalRequest) {
+		fault(r).Code = uint32(code)
+		fault(r).FailureProbability = &probability
+	}
+}
+
+// Payload has the beacon attach a payload of size bytes to the response.
+func Payload(size uint64, typ pb.PayloadType) SignalOption {
+	return func(r *pb.SignalRequest) { r.Payload = &pb.PayloadSpec{Size: size, Type: typ} }
+}
+
+func fault(r *pb.SignalRequest) *pb.Fault {
+	if r.Fault == nil {
+		r.Fault = &pb.Fault{}
+	}
+	return r.Fault
+}
+
+// SignalResponse is a response of the beacon.
+type SignalResponse struct {
+	*pb.SignalResponse
+
+	// Hostname and BeaconName identify the beacon that answered.
+	Hostname   string
+	BeaconName string
+	// Latency is the time the call took, or since the stream started.
+	Latency time.Duration
+}
+
+func newSignalResponse(resp *pb.SignalResponse, start time.Time) *SignalResponse {
+	return &SignalResponse{
+		SignalResponse: resp,
+		Hostname:       resp.GetDetails()["Hostname"],
+		BeaconName:     resp.GetDetails()["BeaconName"],
+		Latency:        time.Since(start),
+	}
+}
+
+// Signal calls the beacon.
+func (c *Client) Signal(ctx context.Context, opts ...SignalOption) (*SignalResponse, error) {
+	req := &pb.SignalRequest{}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	start := time.Now()
+	resp, err := c.beacon.Signal(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSignalResponse(resp, start), nil
+}
+
+// SignalStream opens a stream of count responses, interval apart, and
+// passes them to fn. A count of zero streams until ctx is done. The stream
+// ends early when fn returns an error, which is returned.
+func (c *Client) SignalStream(ctx context.Context, count uint64, interval time.Duration, fn func(*SignalResponse) error, opts ...SignalOption) error {
+	req := &pb.SignalRequest{}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+	stream, err := c.beacon.SignalStream(ctx, &pb.SignalStreamRequest{
+		Message:  req.Message,
+		Interval: durationpb.New(interval),
+		Count:    count,
+		Fault:    req.Fault,
+		Payload:  req.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(newSignalResponse(resp, start)); err != nil {
+			return err
+		}
+	}
+}
+
+// Check returns the serving status of service, "" being the overall status.
+func (c *Client) Check(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	resp, err := c.health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, err
+	}
+
+	return resp.GetStatus(), nil
+}
@@ -0,0 +1,250 @@
+package beaconclient_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+	"github.com/troydai/grpcbeacon/pkg/beaconclient"
+)
+
+func startBeacon(t *testing.T, name string) string {
+	var server *rpc.Server
+	app := fxtest.New(t,
+		fx.Populate(&server),
+		fx.Provide(func() settings.Configuration {
+			return settings.Configuration{
+				Name:    name,
+				Address: "127.0.0.1",
+				Admin:   &settings.Admin{Enabled: true, Token: "secret"},
+			}
+		}),
+		fx.Provide(func() settings.Environment { return settings.Environment{HostName: name + "-host"} }),
+		fx.NopLogger,
+		logging.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+	app.RequireStart()
+	t.Cleanup(app.RequireStop)
+
+	return server.Addrs()[0].String()
+}
+
+func TestClient(t *testing.T) {
+	client, err := beaconclient.Dial(startBeacon(t, "alpha"), beaconclient.WithRequestID())
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("signal", func(t *testing.T) {
+		resp, err := client.Signal(ctx, beaconclient.Message("hello"), beaconclient.Payload(16, pb.PayloadType_PAYLOAD_TYPE_ZEROS))
+		require.NoError(t, err)
+		assert.Equal(t, "alpha", resp.BeaconName)
+		assert.Equal(t, "alpha-host", resp.Hostname)
+		assert.Positive(t, resp.Latency)
+		assert.Len(t, resp.GetPayload(), 16)
+		assert.Len(t, resp.GetRequest().GetHeaders()[beaconclient.MetadataRequestID].GetValues(), 1)
+	})
+
+	t.Run("injected failure", func(t *testing.T) {
+		_, err := client.Signal(ctx, beaconclient.FailWith(codes.ResourceExhausted, 1))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		_, err = client.Signal(ctx, beaconclient.FailWith(codes.ResourceExhausted, 0))
+		assert.NoError(t, err, "a zero probability never fails")
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		var sequences []uint64
+		err := client.SignalStream(ctx, 3, 10*time.Millisecond, func(resp *beaconclient.SignalResponse) error {
+			sequences = append(sequences, resp.GetSequence())
+			assert.Equal(t, "alpha", resp.BeaconName)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1, 2, 3}, sequences)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		st, err := client.Check(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, st)
+	})
+}
+
+func TestClientStreamMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client, err := beaconclient.Dial(startBeacon(t, "alpha"), beaconclient.WithMetrics(registry))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The stream ended by fn is counted although it is never read to the end.
+	errStop := errors.New("stop")
+	err = client.SignalStream(ctx, 0, 10*time.Millisecond, func(*beaconclient.SignalResponse) error { return errStop })
+	require.ErrorIs(t, err, errStop)
+
+	expected := `
+# HELP grpc_client_handled_total Total number of RPCs completed by the client, regardless of success or failure.
+# TYPE grpc_client_handled_total counter
+grpc_client_handled_total{grpc_code="Canceled",grpc_method="SignalStream",grpc_service="troydai.grpcbeacon.v1.BeaconService",grpc_type="server_stream"} 1
+`
+	assert.Eventually(t, func() bool {
+		return testutil.CollectAndCompare(registry, strings.NewReader(expected), "grpc_client_handled_total") == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClientHealthCheckedBalancing(t *testing.T) {
+	alpha, beta := startBeacon(t, "alpha"), startBeacon(t, "beta")
+
+	r := manual.NewBuilderWithScheme("beacons")
+	r.InitialState(resolver.State{Addresses: []resolver.Address{{Addr: alpha}, {Addr: beta}}})
+
+	client, err := beaconclient.Dial(r.Scheme()+":///beacons",
+		beaconclient.WithLoadBalancingPolicy("round_robin"),
+		beaconclient.WithHealthCheck(""),
+		beaconclient.WithDialOptions(grpc.WithResolvers(r)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	names := func() map[string]int {
+		seen := map[string]int{}
+		for i := 0; i < 10; i++ {
+			resp, err := client.Signal(ctx)
+			require.NoError(t, err)
+			seen[resp.BeaconName]++
+		}
+		return seen
+	}
+	assert.Eventually(t, func() bool {
+		seen := names()
+		return seen["alpha"] > 0 && seen["beta"] > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	admin, err := beaconclient.Dial(beta)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Close() })
+	_, err = admin.Admin().SetServingStatus(
+		metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret"),
+		&pb.SetServingStatusRequest{Status: pb.ServingStatus_SERVING_STATUS_NOT_SERVING},
+	)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return names()["beta"] == 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+// flakyBeacon fails the first calls with Unavailable.
+type flakyBeacon struct {
+	pb.UnimplementedBeaconServiceServer
+
+	failures int32
+	calls    atomic.Int32
+}
+
+func (s *flakyBeacon) Signal(context.Context, *pb.SignalRequest) (*pb.SignalResponse, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "not yet")
+	}
+	return &pb.SignalResponse{Reply: "finally"}, nil
+}
+
+func TestClientRetryPolicy(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	flaky := &flakyBeacon{failures: 2}
+	server := grpc.NewServer()
+	pb.RegisterBeaconServiceServer(server, flaky)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	policy := beaconclient.RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       10 * time.Millisecond,
+		MaxBackoff:           50 * time.Millisecond,
+		BackoffMultiplier:    2,
+		RetryableStatusCodes: []codes.Code{codes.Unavailable},
+	}
+	client, err := beaconclient.Dial(lis.Addr().String(), beaconclient.WithRetryPolicy(policy))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.Signal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "finally", resp.GetReply())
+	assert.Equal(t, int32(3), flaky.calls.Load())
+
+	t.Run("exclusive with hedging", func(t *testing.T) {
+		_, err := beaconclient.Dial(lis.Addr().String(),
+			beaconclient.WithRetryPolicy(policy),
+			beaconclient.WithHedgingPolicy(beaconclient.HedgingPolicy{MaxAttempts: 2}),
+		)
+		assert.ErrorContains(t, err, "mutually exclusive")
+	})
+
+	t.Run("sub-millisecond backoff", func(t *testing.T) {
+		short := policy
+		short.InitialBackoff, short.MaxBackoff = 100*time.Nanosecond, time.Microsecond
+		client, err := beaconclient.Dial(lis.Addr().String(), beaconclient.WithRetryPolicy(short))
+		require.NoError(t, err)
+		assert.NoError(t, client.Close())
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		_, err := beaconclient.Dial(lis.Addr().String(), beaconclient.WithRetryPolicy(beaconclient.RetryPolicy{MaxAttempts: 3}))
+		assert.Error(t, err)
+	})
+}
+
+func TestHeaders(t *testing.T) {
+	var h beaconclient.Headers
+	require.NoError(t, h.Set("X-Beacon-Delay: 1s"))
+	require.NoError(t, h.Set("x-tenant:a"))
+	require.NoError(t, h.Set("x-tenant: b "))
+	assert.Error(t, h.Set("no separator"))
+	assert.Error(t, h.Set(" : value"))
+
+	assert.Equal(t, metadata.MD{
+		"x-beacon-delay": {"1s"},
+		"x-tenant":       {"a", "b"},
+	}, h.MD())
+}
@@ -0,0 +1,35 @@
+package beaconclient
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Headers is request metadata written as NAME: VALUE, e.g. x-beacon-delay:
+// 1s. It implements flag.Value for a repeated -H flag.
+type Headers []string
+
+func (h *Headers) String() string { return strings.Join(*h, ", ") }
+
+// Set adds a NAME: VALUE header.
+func (h *Headers) Set(v string) error {
+	name, _, ok := strings.Cut(v, ":")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("expected NAME: VALUE")
+	}
+	*h = append(*h, v)
+	return nil
+}
+
+// MD returns the headers as metadata. Names are lowercased and the spaces
+// around names and values are trimmed.
+func (h Headers) MD() metadata.MD {
+	md := metadata.MD{}
+	for _, v := range h {
+		name, value, _ := strings.Cut(v, ":")
+		md.Append(strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(value))
+	}
+	return md
+}
@@ -0,0 +1,407 @@
+package beaconclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// MetadataRequestID carries the ID of a request.
+const MetadataRequestID = "x-request-id"
+
+// ClientChain holds the client interceptors in the order they run.
+type ClientChain struct {
+	Unary  []grpc.UnaryClientInterceptor
+	Stream []grpc.StreamClientInterceptor
+}
+
+// DialOptions returns the dial options installing the chain.
+func (c ClientChain) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(c.Unary...),
+		grpc.WithChainStreamInterceptor(c.Stream...),
+	}
+}
+
+// AddRequestID adds an x-request-id header to the calls that have none. The
+// ID is shared by the attempts of a call when it runs before hedging.
+func (c *ClientChain) AddRequestID() {
+	c.Unary = append(c.Unary, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withRequestID(ctx), method, req, reply, cc, opts...)
+	})
+	c.Stream = append(c.Stream, func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withRequestID(ctx), desc, cc, method, opts...)
+	})
+}
+
+// AddLogging logs one line per call once it completes.
+func (c *ClientChain) AddLogging(logger *zap.Logger) {
+	l := &clientLog{logger: logger}
+	c.Unary = append(c.Unary, l.unary)
+	c.Stream = append(c.Stream, l.stream)
+}
+
+// AddMetrics collects the client metrics with the same names and labels as
+// the go-grpc-prometheus client interceptors. The collectors are shared by
+// the chains registering to the same registerer.
+func (c *ClientChain) AddMetrics(registerer prometheus.Registerer) error {
+	m, err := newClientMetrics(registerer)
+	if err != nil {
+		return err
+	}
+
+	c.Unary = append(c.Unary, m.unary)
+	c.Stream = append(c.Stream, m.stream)
+	return nil
+}
+
+// HedgingPolicy follows the hedging policy of the gRPC service config, which
+// grpc-go does not implement. Only unary calls are hedged.
+type HedgingPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a call, the original
+	// one included, from 2 to 5.
+	MaxAttempts int
+	// HedgingDelay is the wait before the next attempt is sent while the
+	// previous ones are pending. Zero sends all the attempts at once.
+	HedgingDelay time.Duration
+	// NonFatalStatusCodes are the codes that send the next attempt right away
+	// instead of failing the call.
+	NonFatalStatusCodes []codes.Code
+}
+
+// Validate checks the policy.
+func (p HedgingPolicy) Validate() error {
+	if p.MaxAttempts < 2 || p.MaxAttempts > 5 {
+		return fmt.Errorf("hedging MaxAttempts must be within [2, 5]: %d", p.MaxAttempts)
+	}
+	if p.HedgingDelay < 0 {
+		return fmt.Errorf("hedging delay must not be negative: %s", p.HedgingDelay)
+	}
+
+	return nil
+}
+
+// AddHedging sends the attempts of unary calls as defined by the policy and
+// returns the first response, cancelling the other attempts.
+func (c *ClientChain) AddHedging(policy HedgingPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	c.Unary = append(c.Unary, (&hedging{policy: policy}).unary)
+	return nil
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if len(md.Get(MetadataRequestID)) > 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, MetadataRequestID, newRequestID())
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// clientLog logs the calls made by a client.
+type clientLog struct {
+	logger *zap.Logger
+}
+
+func (l *clientLog) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	l.log(ctx, cc, method, start, err)
+
+	return err
+}
+
+func (l *clientLog) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		l.log(ctx, cc, method, start, err)
+		return nil, err
+	}
+
+	return newDoneStream(ctx, desc, cs, func(err error) { l.log(ctx, cc, method, start, err) }), nil
+}
+
+func (l *clientLog) log(ctx context.Context, cc *grpc.ClientConn, method string, start time.Time, err error) {
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("target", cc.Target()),
+		zap.Stringer("code", status.Code(err)),
+		zap.Duration("latency", time.Since(start)),
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if id := md.Get(MetadataRequestID); len(id) > 0 {
+			fields = append(fields, zap.String("requestID", id[0]))
+		}
+	}
+	if err != nil {
+		fields = append(fields, zap.String("error", status.Convert(err).Message()))
+	}
+
+	l.logger.Info("call", fields...)
+}
+
+// clientMetrics collects the metrics of the calls made by a client.
+type clientMetrics struct {
+	started  *prometheus.CounterVec
+	handled  *prometheus.CounterVec
+	handling *prometheus.HistogramVec
+}
+
+func newClientMetrics(registerer prometheus.Registerer) (*clientMetrics, error) {
+	labels := []string{"grpc_type", "grpc_service", "grpc_method"}
+	m := &clientMetrics{
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_client_started_total",
+			Help: "Total number of RPCs started on the client.",
+		}, labels),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_client_handled_total",
+			Help: "Total number of RPCs completed by the client, regardless of success or failure.",
+		}, append(labels, "grpc_code")),
+		handling: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_client_handling_seconds",
+			Help:    "Histogram of response latency (seconds) of the gRPC until it is finished by the application.",
+			Buckets: prometheus.DefBuckets,
+		}, labels),
+	}
+
+	var err error
+	if m.started, err = register(registerer, m.started); err != nil {
+		return nil, err
+	}
+	if m.handled, err = register(registerer, m.handled); err != nil {
+		return nil, err
+	}
+	if m.handling, err = register(registerer, m.handling); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// register registers c, or returns the collector registered before it.
+func register[C prometheus.Collector](registerer prometheus.Registerer, c C) (C, error) {
+	err := registerer.Register(c)
+	if err == nil {
+		return c, nil
+	}
+
+	var already prometheus.AlreadyRegisteredError
+	if errors.As(err, &already) {
+		if existing, ok := already.ExistingCollector.(C); ok {
+			return existing, nil
+		}
+	}
+
+	return c, fmt.Errorf("fail to register client metrics: %w", err)
+}
+
+func (m *clientMetrics) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	done := m.begin("unary", method)
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	done(err)
+
+	return err
+}
+
+func (m *clientMetrics) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	typ := "bidi_stream"
+	switch {
+	case desc.ClientStreams && !desc.ServerStreams:
+		typ = "client_stream"
+	case !desc.ClientStreams && desc.ServerStreams:
+		typ = "server_stream"
+	}
+
+	done := m.begin(typ, method)
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+
+	return newDoneStream(ctx, desc, cs, done), nil
+}
+
+func (m *clientMetrics) begin(typ, fullMethod string) func(error) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	m.started.WithLabelValues(typ, service, method).Inc()
+
+	start := time.Now()
+	return func(err error) {
+		m.handled.WithLabelValues(typ, service, method, status.Code(err).String()).Inc()
+		m.handling.WithLabelValues(typ, service, method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// doneStream calls done once, when the stream ends: on the error or EOF of
+// RecvMsg, on the response of a stream without server streaming, e.g. from
+// CloseAndRecv, or when ctx is done before, e.g. when the caller abandons
+// the stream by cancelling it.
+type doneStream struct {
+	grpc.ClientStream
+
+	desc *grpc.StreamDesc
+	once sync.Once
+	done func(error)
+	stop func() bool
+}
+
+func newDoneStream(ctx context.Context, desc *grpc.StreamDesc, cs grpc.ClientStream, done func(error)) *doneStream {
+	s := &doneStream{ClientStream: cs, desc: desc, done: done}
+	s.stop = context.AfterFunc(ctx, func() {
+		s.finish(status.FromContextError(ctx.Err()).Err())
+	})
+
+	return s
+}
+
+func (s *doneStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case errors.Is(err, io.EOF):
+		s.finish(nil)
+	case err != nil:
+		s.finish(err)
+	case !s.desc.ServerStreams:
+		s.finish(nil)
+	}
+
+	return err
+}
+
+func (s *doneStream) finish(err error) {
+	s.once.Do(func() {
+		s.stop()
+		s.done(err)
+	})
+}
+
+// hedging sends the attempts of a call concurrently.
+type hedging struct {
+	policy HedgingPolicy
+}
+
+type attempt struct {
+	reply any
+	err   error
+	// apply sets the header, trailer and peer of the attempt to the options
+	// of the caller.
+	apply func()
+}
+
+func (h *hedging) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	msg, ok := reply.(proto.Message)
+	if !ok {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan attempt, h.policy.MaxAttempts)
+	send := func() {
+		r := msg.ProtoReflect().New().Interface()
+		attemptOpts, apply := attemptCallOptions(opts)
+		go func() {
+			results <- attempt{reply: r, err: invoker(ctx, method, req, r, cc, attemptOpts...), apply: apply}
+		}()
+	}
+
+	send()
+	sent, pending := 1, 1
+	timer := time.NewTimer(h.policy.HedgingDelay)
+	defer timer.Stop()
+
+	var last attempt
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if sent < h.policy.MaxAttempts {
+				send()
+				sent++
+				pending++
+				timer.Reset(h.policy.HedgingDelay)
+			}
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				proto.Merge(msg, r.reply.(proto.Message))
+				r.apply()
+				return nil
+			}
+			last = r
+			if !slices.Contains(h.policy.NonFatalStatusCodes, status.Code(r.err)) {
+				r.apply()
+				return r.err
+			}
+			if sent < h.policy.MaxAttempts {
+				send()
+				sent++
+				pending++
+				timer.Reset(h.policy.HedgingDelay)
+			}
+		}
+	}
+
+	last.apply()
+	return last.err
+}
+
+// attemptCallOptions gives an attempt its own header, trailer and peer,
+// which the concurrent attempts would otherwise write to the variables of
+// the caller at the same time. apply copies them to the caller's.
+func attemptCallOptions(opts []grpc.CallOption) ([]grpc.CallOption, func()) {
+	own := make([]grpc.CallOption, len(opts))
+	var applies []func()
+	for i, opt := range opts {
+		switch o := opt.(type) {
+		case grpc.HeaderCallOption:
+			md := &metadata.MD{}
+			own[i] = grpc.Header(md)
+			applies = append(applies, func() { *o.HeaderAddr = *md })
+		case grpc.TrailerCallOption:
+			md := &metadata.MD{}
+			own[i] = grpc.Trailer(md)
+			applies = append(applies, func() { *o.TrailerAddr = *md })
+		case grpc.PeerCallOption:
+			p := &peer.Peer{}
+			own[i] = grpc.Peer(p)
+			applies = append(applies, func() { *o.PeerAddr = *p })
+		default:
+			own[i] = opt
+		}
+	}
+
+	return own, func() {
+		for _, apply := range applies {
+			apply()
+		}
+	}
+}
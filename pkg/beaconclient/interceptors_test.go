@@ -0,0 +1,267 @@
+package beaconclient_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/pkg/beaconclient"
+)
+
+const _signalMethod = "/troydai.grpcbeacon.v1.BeaconService/Signal"
+
+func newTestConn(t *testing.T) *grpc.ClientConn {
+	conn, err := grpc.NewClient("passthrough:///beacon", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestClientRequestID(t *testing.T) {
+	var chain beaconclient.ClientChain
+	chain.AddRequestID()
+
+	var ids []string
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		ids = append(ids, md.Get(beaconclient.MetadataRequestID)...)
+		return nil
+	}
+
+	conn := newTestConn(t)
+	require.NoError(t, chain.Unary[0](context.Background(), _signalMethod, nil, nil, conn, invoker))
+	require.NoError(t, chain.Unary[0](context.Background(), _signalMethod, nil, nil, conn, invoker))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), beaconclient.MetadataRequestID, "given")
+	require.NoError(t, chain.Unary[0](ctx, _signalMethod, nil, nil, conn, invoker))
+
+	require.Len(t, ids, 3)
+	assert.Len(t, ids[0], 32)
+	assert.NotEqual(t, ids[0], ids[1])
+	assert.Equal(t, "given", ids[2])
+}
+
+func TestClientLogging(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	var chain beaconclient.ClientChain
+	chain.AddRequestID()
+	chain.AddLogging(zap.New(core))
+
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "down")
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), beaconclient.MetadataRequestID, "req-1")
+	unary := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return chain.Unary[1](ctx, method, req, reply, cc, invoker, opts...)
+	}
+	err := chain.Unary[0](ctx, _signalMethod, nil, nil, newTestConn(t), unary)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, _signalMethod, fields["method"])
+	assert.Equal(t, "passthrough:///beacon", fields["target"])
+	assert.Equal(t, "Unavailable", fields["code"])
+	assert.Equal(t, "req-1", fields["requestID"])
+	assert.Equal(t, "down", fields["error"])
+}
+
+func TestClientMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	// Chains sharing a registry share the collectors.
+	var first, second beaconclient.ClientChain
+	require.NoError(t, first.AddMetrics(registry))
+	require.NoError(t, second.AddMetrics(registry))
+
+	conn := newTestConn(t)
+	ok := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error { return nil }
+	failed := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.Internal, "boom")
+	}
+	require.NoError(t, first.Unary[0](context.Background(), _signalMethod, nil, nil, conn, ok))
+	require.Error(t, second.Unary[0](context.Background(), _signalMethod, nil, nil, conn, failed))
+
+	expected := `
+# HELP grpc_client_started_total Total number of RPCs started on the client.
+# TYPE grpc_client_started_total counter
+grpc_client_started_total{grpc_method="Signal",grpc_service="troydai.grpcbeacon.v1.BeaconService",grpc_type="unary"} 2
+# HELP grpc_client_handled_total Total number of RPCs completed by the client, regardless of success or failure.
+# TYPE grpc_client_handled_total counter
+grpc_client_handled_total{grpc_code="Internal",grpc_method="Signal",grpc_service="troydai.grpcbeacon.v1.BeaconService",grpc_type="unary"} 1
+grpc_client_handled_total{grpc_code="OK",grpc_method="Signal",grpc_service="troydai.grpcbeacon.v1.BeaconService",grpc_type="unary"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(registry, strings.NewReader(expected), "grpc_client_started_total", "grpc_client_handled_total"))
+	assert.Equal(t, 1, testutil.CollectAndCount(registry, "grpc_client_handling_seconds"))
+}
+
+// recvStream returns the errors of RecvMsg in order.
+type recvStream struct {
+	grpc.ClientStream
+
+	errs []error
+}
+
+func (s *recvStream) RecvMsg(any) error {
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+	return err
+}
+
+func TestClientStreamLogging(t *testing.T) {
+	open := func(t *testing.T, ctx context.Context, desc *grpc.StreamDesc, errs ...error) (grpc.ClientStream, *observer.ObservedLogs) {
+		core, logs := observer.New(zap.InfoLevel)
+		var chain beaconclient.ClientChain
+		chain.AddLogging(zap.New(core))
+
+		streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return &recvStream{errs: errs}, nil
+		}
+		cs, err := chain.Stream[0](ctx, desc, newTestConn(t), _signalMethod, streamer)
+		require.NoError(t, err)
+		return cs, logs
+	}
+	code := func(logs *observer.ObservedLogs) any {
+		return logs.All()[0].ContextMap()["code"]
+	}
+
+	t.Run("server streaming ends on EOF", func(t *testing.T) {
+		cs, logs := open(t, context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, io.EOF)
+		require.NoError(t, cs.RecvMsg(nil))
+		assert.Zero(t, logs.Len())
+		require.ErrorIs(t, cs.RecvMsg(nil), io.EOF)
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, "OK", code(logs))
+	})
+
+	t.Run("client streaming ends on the response", func(t *testing.T) {
+		cs, logs := open(t, context.Background(), &grpc.StreamDesc{ClientStreams: true}, nil, io.EOF)
+		require.NoError(t, cs.RecvMsg(nil))
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, "OK", code(logs))
+
+		require.ErrorIs(t, cs.RecvMsg(nil), io.EOF)
+		assert.Equal(t, 1, logs.Len(), "the stream is logged once")
+	})
+
+	t.Run("abandoned stream ends on cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		_, logs := open(t, ctx, &grpc.StreamDesc{ServerStreams: true})
+		assert.Zero(t, logs.Len())
+
+		cancel()
+		require.Eventually(t, func() bool { return logs.Len() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, "Canceled", code(logs))
+	})
+}
+
+func TestClientHedging(t *testing.T) {
+	newHedging := func(t *testing.T, policy beaconclient.HedgingPolicy) grpc.UnaryClientInterceptor {
+		var chain beaconclient.ClientChain
+		require.NoError(t, chain.AddHedging(policy))
+		return chain.Unary[0]
+	}
+	conn := newTestConn(t)
+
+	t.Run("slow attempt is hedged", func(t *testing.T) {
+		hedge := newHedging(t, beaconclient.HedgingPolicy{MaxAttempts: 3, HedgingDelay: 20 * time.Millisecond})
+
+		var attempts atomic.Int32
+		invoker := func(ctx context.Context, _ string, _, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			if attempts.Add(1) == 1 {
+				<-ctx.Done()
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			reply.(*pb.SignalResponse).Reply = "hedged"
+			return nil
+		}
+
+		reply := &pb.SignalResponse{}
+		require.NoError(t, hedge(context.Background(), _signalMethod, &pb.SignalRequest{}, reply, conn, invoker))
+		assert.Equal(t, "hedged", reply.Reply)
+		assert.Equal(t, int32(2), attempts.Load())
+	})
+
+	t.Run("header of the winning attempt", func(t *testing.T) {
+		hedge := newHedging(t, beaconclient.HedgingPolicy{MaxAttempts: 2, HedgingDelay: 20 * time.Millisecond})
+
+		lost := make(chan struct{})
+		var attempts atomic.Int32
+		invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+			n := attempts.Add(1)
+			if n == 1 {
+				<-ctx.Done()
+				defer close(lost)
+			}
+			// As grpc-go does once the call is over.
+			for _, opt := range opts {
+				if o, ok := opt.(grpc.HeaderCallOption); ok {
+					*o.HeaderAddr = metadata.Pairs("attempt", fmt.Sprint(n))
+				}
+			}
+			if n == 1 {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return nil
+		}
+
+		var header metadata.MD
+		require.NoError(t, hedge(context.Background(), _signalMethod, &pb.SignalRequest{}, &pb.SignalResponse{}, conn, invoker, grpc.Header(&header)))
+		<-lost
+		assert.Equal(t, []string{"2"}, header.Get("attempt"))
+	})
+
+	t.Run("non fatal codes send the next attempt", func(t *testing.T) {
+		hedge := newHedging(t, beaconclient.HedgingPolicy{
+			MaxAttempts:         3,
+			HedgingDelay:        time.Hour,
+			NonFatalStatusCodes: []codes.Code{codes.Unavailable},
+		})
+
+		var attempts atomic.Int32
+		invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			attempts.Add(1)
+			return status.Error(codes.Unavailable, "down")
+		}
+
+		err := hedge(context.Background(), _signalMethod, &pb.SignalRequest{}, &pb.SignalResponse{}, conn, invoker)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, int32(3), attempts.Load())
+	})
+
+	t.Run("fatal codes fail the call", func(t *testing.T) {
+		hedge := newHedging(t, beaconclient.HedgingPolicy{MaxAttempts: 3, HedgingDelay: time.Hour})
+
+		var attempts atomic.Int32
+		invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			attempts.Add(1)
+			return status.Error(codes.InvalidArgument, "bad")
+		}
+
+		err := hedge(context.Background(), _signalMethod, &pb.SignalRequest{}, &pb.SignalResponse{}, conn, invoker)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		var chain beaconclient.ClientChain
+		assert.Error(t, chain.AddHedging(beaconclient.HedgingPolicy{MaxAttempts: 1}))
+		assert.Error(t, chain.AddHedging(beaconclient.HedgingPolicy{MaxAttempts: 6}))
+	})
+}
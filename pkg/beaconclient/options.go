@@ -0,0 +1,301 @@
+package beaconclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+type (
+	// Option configures Dial.
+	Option func(*options)
+
+	options struct {
+		tlsConfig     *tls.Config
+		tlsFiles      *TLS
+		keepalive     *keepalive.ClientParameters
+		lbPolicy      string
+		retry         *RetryPolicy
+		hedging       *HedgingPolicy
+		healthService *string
+		logger        *zap.Logger
+		registerer    prometheus.Registerer
+		requestID     bool
+		dialOptions   []grpc.DialOption
+	}
+
+	// TLS locates the files securing the connection.
+	TLS struct {
+		// CAFile verifies the server. The system roots are used when empty.
+		CAFile string
+		// CertFile and KeyFile are the client certificate for mutual TLS.
+		CertFile string
+		KeyFile  string
+		// ServerName is sent through SNI and verified, the host of the target
+		// by default.
+		ServerName         string
+		InsecureSkipVerify bool
+	}
+
+	// RetryPolicy follows the retry policy of the gRPC service config and
+	// applies to every method.
+	RetryPolicy struct {
+		// MaxAttempts is the maximum number of attempts of a call, the
+		// original one included, from 2 to 5.
+		MaxAttempts       int
+		InitialBackoff    time.Duration
+		MaxBackoff        time.Duration
+		BackoffMultiplier float64
+		// RetryableStatusCodes are the codes the call is retried on.
+		RetryableStatusCodes []codes.Code
+	}
+)
+
+// WithTLS secures the connection with the files of t. The connection is in
+// plaintext without a TLS option.
+func WithTLS(t TLS) Option {
+	return func(o *options) { o.tlsFiles = &t }
+}
+
+// WithTLSConfig secures the connection with config.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *options) { o.tlsConfig = config }
+}
+
+// WithKeepalive sends keepalive pings as set by params.
+func WithKeepalive(params keepalive.ClientParameters) Option {
+	return func(o *options) { o.keepalive = &params }
+}
+
+// WithLoadBalancingPolicy selects the load balancing policy, e.g.
+// round_robin, instead of the one of the resolver.
+func WithLoadBalancingPolicy(name string) Option {
+	return func(o *options) { o.lbPolicy = name }
+}
+
+// WithRetryPolicy retries the calls failing with a retryable code. It is
+// exclusive with WithHedgingPolicy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) { o.retry = &p }
+}
+
+// WithHedgingPolicy hedges the unary calls. It is exclusive with
+// WithRetryPolicy.
+func WithHedgingPolicy(p HedgingPolicy) Option {
+	return func(o *options) { o.hedging = &p }
+}
+
+// WithHealthCheck has the load balancer only pick the backends reporting
+// service as serving through the health service, "" being the overall
+// status. pick_first ignores it, use it with round_robin.
+func WithHealthCheck(service string) Option {
+	return func(o *options) { o.healthService = &service }
+}
+
+// WithLogger logs one line per call.
+func WithLogger(logger *zap.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithMetrics collects the grpc_client_* metrics into registerer.
+func WithMetrics(registerer prometheus.Registerer) Option {
+	return func(o *options) { o.registerer = registerer }
+}
+
+// WithRequestID adds an x-request-id header to the calls that have none.
+func WithRequestID() Option {
+	return func(o *options) { o.requestID = true }
+}
+
+// WithDialOptions adds grpc dial options, applied after the other options.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = append(o.dialOptions, opts...) }
+}
+
+func (o *options) grpcOptions() ([]grpc.DialOption, error) {
+	if o.retry != nil && o.hedging != nil {
+		return nil, fmt.Errorf("retry and hedging policies are mutually exclusive")
+	}
+
+	creds, err := o.credentials()
+	if err != nil {
+		return nil, err
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+
+	if o.keepalive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(*o.keepalive))
+	}
+
+	serviceConfig, err := o.serviceConfig()
+	if err != nil {
+		return nil, err
+	}
+	if serviceConfig != "" {
+		opts = append(opts, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+
+	// The request ID is set first so the log line and every hedged attempt
+	// carry it, and the metrics see a hedged call once.
+	var chain ClientChain
+	if o.requestID {
+		chain.AddRequestID()
+	}
+	if o.logger != nil {
+		chain.AddLogging(o.logger)
+	}
+	if o.registerer != nil {
+		if err := chain.AddMetrics(o.registerer); err != nil {
+			return nil, err
+		}
+	}
+	if o.hedging != nil {
+		if err := chain.AddHedging(*o.hedging); err != nil {
+			return nil, err
+		}
+	}
+	opts = append(opts, chain.DialOptions()...)
+
+	return append(opts, o.dialOptions...), nil
+}
+
+func (o *options) credentials() (credentials.TransportCredentials, error) {
+	switch {
+	case o.tlsConfig != nil:
+		return credentials.NewTLS(o.tlsConfig), nil
+	case o.tlsFiles != nil:
+		config, err := o.tlsFiles.config()
+		if err != nil {
+			return nil, err
+		}
+		return credentials.NewTLS(config), nil
+	}
+
+	return insecure.NewCredentials(), nil
+}
+
+func (t *TLS) config() (*tls.Config, error) {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return nil, fmt.Errorf("client certificate and key must be set together")
+	}
+
+	config := &tls.Config{
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify, // #nosec G402 -- opted in by the caller
+	}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read CA certificate: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", t.CAFile)
+		}
+	}
+
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("fail to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// serviceConfig returns the service config JSON, empty when nothing is set.
+func (o *options) serviceConfig() (string, error) {
+	type (
+		retryPolicy struct {
+			MaxAttempts          int      `json:"maxAttempts"`
+			InitialBackoff       string   `json:"initialBackoff"`
+			MaxBackoff           string   `json:"maxBackoff"`
+			BackoffMultiplier    float64  `json:"backoffMultiplier"`
+			RetryableStatusCodes []string `json:"retryableStatusCodes"`
+		}
+		methodConfig struct {
+			Name        []struct{}   `json:"name"`
+			RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+		}
+		healthCheckConfig struct {
+			ServiceName string `json:"serviceName"`
+		}
+		serviceConfig struct {
+			LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig,omitempty"`
+			MethodConfig        []methodConfig        `json:"methodConfig,omitempty"`
+			HealthCheckConfig   *healthCheckConfig    `json:"healthCheckConfig,omitempty"`
+		}
+	)
+
+	var sc serviceConfig
+	if o.lbPolicy != "" {
+		sc.LoadBalancingConfig = []map[string]struct{}{{o.lbPolicy: {}}}
+	}
+	if o.healthService != nil {
+		sc.HealthCheckConfig = &healthCheckConfig{ServiceName: *o.healthService}
+	}
+	if p := o.retry; p != nil {
+		if p.MaxAttempts < 2 || p.InitialBackoff <= 0 || p.MaxBackoff <= 0 || p.BackoffMultiplier <= 0 || len(p.RetryableStatusCodes) == 0 {
+			return "", fmt.Errorf("retry policy needs MaxAttempts of 2 or more, positive backoffs and multiplier, and retryable codes")
+		}
+
+		rp := &retryPolicy{
+			MaxAttempts:       p.MaxAttempts,
+			InitialBackoff:    durationJSON(p.InitialBackoff),
+			MaxBackoff:        durationJSON(p.MaxBackoff),
+			BackoffMultiplier: p.BackoffMultiplier,
+		}
+		for _, c := range p.RetryableStatusCodes {
+			rp.RetryableStatusCodes = append(rp.RetryableStatusCodes, codeName(c))
+		}
+		// An empty name is the default of every method.
+		sc.MethodConfig = []methodConfig{{Name: []struct{}{{}}, RetryPolicy: rp}}
+	}
+
+	if sc.LoadBalancingConfig == nil && sc.HealthCheckConfig == nil && sc.MethodConfig == nil {
+		return "", nil
+	}
+
+	b, err := json.Marshal(sc)
+	if err != nil {
+		return "", fmt.Errorf("fail to marshal service config: %w", err)
+	}
+	return string(b), nil
+}
+
+// durationJSON formats d as a JSON duration, e.g. 0.0000001s, which has no
+// exponent.
+func durationJSON(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// codeName returns the name of c in the service config, e.g. UNAVAILABLE.
+func codeName(c codes.Code) string {
+	var b strings.Builder
+	var previous rune
+	for _, r := range c.String() {
+		if unicode.IsUpper(r) && unicode.IsLower(previous) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+		previous = r
+	}
+	return strings.ToUpper(b.String())
+}
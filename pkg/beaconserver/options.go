@@ -0,0 +1,198 @@
+package beaconserver
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+// The configuration types of the server, as read from the TOML config file.
+type (
+	Configuration            = settings.Configuration
+	Environment              = settings.Environment
+	Listener                 = settings.Listener
+	Logging                  = settings.Logging
+	Redaction                = settings.Redaction
+	Admin                    = settings.Admin
+	Shutdown                 = settings.Shutdown
+	Metrics                  = settings.Metrics
+	Tracing                  = settings.Tracing
+	Interceptors             = settings.Interceptors
+	AccessLog                = settings.AccessLog
+	TLSConfiguration         = settings.TLSConfiguration
+	TLSMisbehavior           = settings.TLSMisbehavior
+	CertificateConfiguration = settings.CertificateConfiguration
+)
+
+type (
+	// Register registers services next to the beacon. The registrar of a
+	// listener only keeps the services the listener exposes.
+	Register = rpc.GRPCRegister
+
+	// Option configures New.
+	Option func(*options)
+
+	options struct {
+		config        *Configuration
+		configFile    string
+		env           *Environment
+		logger        *zap.Logger
+		registers     []Register
+		unary         []grpc.UnaryServerInterceptor
+		stream        []grpc.StreamServerInterceptor
+		serverOptions []grpc.ServerOption
+		fxOptions     []fx.Option
+	}
+)
+
+// RegisterFunc turns fn into a Register.
+func RegisterFunc(fn func(grpc.ServiceRegistrar) error) Register {
+	return rpc.GRPCRegisterFromFn(fn)
+}
+
+// WithConfig configures the server with c. Without WithConfig or
+// WithConfigFile, the server runs the default configuration of the beacon
+// binary, which listens on 127.0.0.1:8080.
+func WithConfig(c Configuration) Option {
+	return func(o *options) { o.config = &c }
+}
+
+// WithConfigFile reads the configuration from the TOML file at path.
+func WithConfigFile(path string) Option {
+	return func(o *options) { o.configFile = path }
+}
+
+// WithEnvironment replaces the environment, which is read from the
+// HOSTNAME and BEACON_ADMIN_TOKEN environment variables by default.
+func WithEnvironment(e Environment) Option {
+	return func(o *options) { o.env = &e }
+}
+
+// WithLogger replaces the logger built from the logging configuration.
+func WithLogger(logger *zap.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithRegisters adds services to the server.
+func WithRegisters(registers ...Register) Option {
+	return func(o *options) { o.registers = append(o.registers, registers...) }
+}
+
+// WithUnaryInterceptors adds unary interceptors. They run after the
+// configured interceptors, in the order they are given.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(o *options) { o.unary = append(o.unary, interceptors...) }
+}
+
+// WithStreamInterceptors adds stream interceptors. They run after the
+// configured interceptors, in the order they are given.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(o *options) { o.stream = append(o.stream, interceptors...) }
+}
+
+// WithServerOptions adds options to the gRPC servers of every listener.
+func WithServerOptions(opts ...grpc.ServerOption) Option {
+	return func(o *options) { o.serverOptions = append(o.serverOptions, opts...) }
+}
+
+// WithFxOptions adds options to the fx application hosting the server, e.g.
+// to provide more fx modules contributing to the grpc_registers group.
+func WithFxOptions(opts ...fx.Option) Option {
+	return func(o *options) { o.fxOptions = append(o.fxOptions, opts...) }
+}
+
+// configuration returns the configuration of the server.
+func (o *options) configuration() (Configuration, error) {
+	switch {
+	case o.config != nil:
+		if err := o.config.Validate(); err != nil {
+			return Configuration{}, fmt.Errorf("invalid configuration: %w", err)
+		}
+		return *o.config, nil
+	case o.configFile != "":
+		return settings.ReadConfigFile(o.configFile)
+	default:
+		return settings.DefaultConfig(), nil
+	}
+}
+
+// environment returns the environment of the server.
+func (o *options) environment() (Environment, error) {
+	if o.env != nil {
+		return *o.env, nil
+	}
+
+	return settings.LoadEnvironment()
+}
+
+// fxOption returns the fx options contributing the registers, interceptors
+// and server options to the groups the rpc module consumes.
+func (o *options) fxOption() fx.Option {
+	provides := []any{
+		fx.Annotate(
+			func() []Register { return o.registers },
+			fx.ResultTags(`group:"grpc_registers,flatten"`),
+		),
+		fx.Annotate(
+			func() []grpc.ServerOption { return o.serverOptions },
+			fx.ResultTags(`group:"grpc_server_options,flatten"`),
+		),
+	}
+	// The values of a group are not ordered, so the interceptors are chained
+	// into one.
+	if len(o.unary) > 0 {
+		provides = append(provides, fx.Annotate(
+			func() grpc.UnaryServerInterceptor { return chainUnary(o.unary) },
+			fx.ResultTags(`group:"grpc_unary_interceptors"`),
+		))
+	}
+	if len(o.stream) > 0 {
+		provides = append(provides, fx.Annotate(
+			func() grpc.StreamServerInterceptor { return chainStream(o.stream) },
+			fx.ResultTags(`group:"grpc_stream_interceptors"`),
+		))
+	}
+
+	opts := append([]fx.Option{fx.Provide(provides...)}, o.fxOptions...)
+	if o.logger != nil {
+		opts = append(opts, fx.Replace(o.logger))
+	}
+
+	return fx.Options(opts...)
+}
+
+// chainUnary runs the interceptors in order, the first one being the
+// outermost.
+func chainUnary(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// chainStream runs the interceptors in order, the first one being the
+// outermost.
+func chainStream(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(srv any, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, next)
+			}
+		}
+
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,86 @@
+// Package beaconserver hosts the beacon in another application. It
+// assembles the fx modules of the beacon binary, the beacon, health, admin,
+// metrics and tracing, from a configuration struct rather than the -config
+// flag, and serves the services of the application next to them.
+package beaconserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.uber.org/fx"
+
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/metrics"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/tracing"
+)
+
+// Server is a beacon server. Its listeners are bound by New, so the
+// addresses are known before Start.
+type Server struct {
+	app    *fx.App
+	server *rpc.Server
+}
+
+// New builds the server and binds its listeners.
+func New(opts ...Option) (*Server, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	config, err := o.configuration()
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := o.environment()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{}
+	s.app = fx.New(
+		fx.Supply(config, env),
+		logging.Module,
+		metrics.Module,
+		tracing.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+		o.fxOption(),
+		fx.Populate(&s.server),
+	)
+	if err := s.app.Err(); err != nil {
+		return nil, fmt.Errorf("fail to build server: %w", err)
+	}
+
+	return s, nil
+}
+
+// Start serves the listeners. It returns once every listener is serving.
+func (s *Server) Start(ctx context.Context) error {
+	return s.app.Start(ctx)
+}
+
+// Stop drains and stops the server. The connections are closed when ctx
+// expires before the calls complete.
+func (s *Server) Stop(ctx context.Context) error {
+	return s.app.Stop(ctx)
+}
+
+// Addrs returns the bound addresses of the listeners, in the order they are
+// configured.
+func (s *Server) Addrs() []net.Addr {
+	return s.server.Addrs()
+}
+
+// Addr returns the bound address of the named listener. Listeners without a
+// name are named after their index, e.g. #0.
+func (s *Server) Addr(name string) (net.Addr, bool) {
+	return s.server.Addr(name)
+}
@@ -0,0 +1,197 @@
+package beaconserver_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	testgrpc "google.golang.org/grpc/interop/grpc_testing"
+
+	"github.com/troydai/grpcbeacon/pkg/beaconclient"
+	"github.com/troydai/grpcbeacon/pkg/beaconserver"
+)
+
+type testService struct {
+	testgrpc.UnimplementedTestServiceServer
+}
+
+func (testService) EmptyCall(context.Context, *testgrpc.Empty) (*testgrpc.Empty, error) {
+	return &testgrpc.Empty{}, nil
+}
+
+func start(t *testing.T, opts ...beaconserver.Option) *beaconclient.Client {
+	server, err := beaconserver.New(append([]beaconserver.Option{
+		beaconserver.WithEnvironment(beaconserver.Environment{HostName: "embedded-host"}),
+		beaconserver.WithLogger(zap.NewNop()),
+	}, opts...)...)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, server.Start(ctx))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		assert.NoError(t, server.Stop(ctx))
+	})
+
+	require.Len(t, server.Addrs(), 1)
+	addr, ok := server.Addr("#0")
+	require.True(t, ok)
+	assert.Equal(t, server.Addrs()[0], addr)
+
+	client, err := beaconclient.Dial(addr.String())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, client.Close()) })
+
+	return client
+}
+
+func TestServer(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		methods []string
+	)
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			mu.Lock()
+			methods = append(methods, name+" "+info.FullMethod)
+			mu.Unlock()
+			return handler(ctx, req)
+		}
+	}
+
+	client := start(t,
+		beaconserver.WithConfig(beaconserver.Configuration{
+			Name:    "embedded",
+			Address: "127.0.0.1",
+		}),
+		beaconserver.WithRegisters(beaconserver.RegisterFunc(func(s grpc.ServiceRegistrar) error {
+			testgrpc.RegisterTestServiceServer(s, testService{})
+			return nil
+		})),
+		beaconserver.WithUnaryInterceptors(record("first"), record("second")),
+		beaconserver.WithServerOptions(grpc.MaxRecvMsgSize(1<<20)),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.Signal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "embedded", resp.BeaconName)
+	assert.Equal(t, "embedded-host", resp.Hostname)
+
+	_, err = testgrpc.NewTestServiceClient(client.Conn()).EmptyCall(ctx, &testgrpc.Empty{})
+	require.NoError(t, err)
+
+	status, err := client.Check(ctx, "grpc.testing.TestService")
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{
+		"first /troydai.grpcbeacon.v1.BeaconService/Signal",
+		"second /troydai.grpcbeacon.v1.BeaconService/Signal",
+		"first /grpc.testing.TestService/EmptyCall",
+		"second /grpc.testing.TestService/EmptyCall",
+		"first /grpc.health.v1.Health/Check",
+		"second /grpc.health.v1.Health/Check",
+	}, methods)
+}
+
+func TestServerConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beacon.toml")
+	require.NoError(t, os.WriteFile(path, []byte("name = \"from-file\"\naddress = \"127.0.0.1\"\n"), 0o600))
+
+	client := start(t, beaconserver.WithConfigFile(path))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.Signal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", resp.BeaconName)
+}
+
+func TestServerInvalid(t *testing.T) {
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := beaconserver.New(beaconserver.WithConfig(beaconserver.Configuration{
+			Listeners: []beaconserver.Listener{{Network: "udp", Address: "127.0.0.1:0"}},
+		}))
+		assert.ErrorContains(t, err, `unknown network "udp"`)
+	})
+
+	t.Run("missing config file", func(t *testing.T) {
+		_, err := beaconserver.New(beaconserver.WithConfigFile(filepath.Join(t.TempDir(), "missing.toml")))
+		assert.ErrorContains(t, err, "fail to decode config file")
+	})
+
+	t.Run("unknown interceptor", func(t *testing.T) {
+		_, err := beaconserver.New(
+			beaconserver.WithLogger(zap.NewNop()),
+			beaconserver.WithConfig(beaconserver.Configuration{
+				Address:      "127.0.0.1",
+				Interceptors: &beaconserver.Interceptors{Chain: []string{"unknown"}},
+			}),
+		)
+		assert.ErrorContains(t, err, `unknown interceptor "unknown"`)
+	})
+}
+
+func TestServerFailedStartReleasesPorts(t *testing.T) {
+	freePort := func(t *testing.T) int {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer lis.Close()
+		return lis.Addr().(*net.TCPAddr).Port
+	}
+	listenable := func(t *testing.T, port int) {
+		lis, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		require.NoError(t, err)
+		require.NoError(t, lis.Close())
+	}
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		metricsPort := freePort(t)
+		_, err := beaconserver.New(
+			beaconserver.WithLogger(zap.NewNop()),
+			beaconserver.WithConfig(beaconserver.Configuration{
+				Address:      "127.0.0.1",
+				Metrics:      &beaconserver.Metrics{Enabled: true, Address: "127.0.0.1", Port: metricsPort},
+				Interceptors: &beaconserver.Interceptors{Chain: []string{"unknown"}},
+			}),
+		)
+		require.Error(t, err)
+		listenable(t, metricsPort)
+	})
+
+	t.Run("address file not written", func(t *testing.T) {
+		port := freePort(t)
+		server, err := beaconserver.New(
+			beaconserver.WithLogger(zap.NewNop()),
+			beaconserver.WithConfig(beaconserver.Configuration{
+				Address:     "127.0.0.1",
+				Port:        port,
+				AddressFile: filepath.Join(t.TempDir(), "missing", "beacon.addr"),
+			}),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		assert.ErrorContains(t, server.Start(ctx), "fail to create address file")
+		listenable(t, port)
+	})
+}
@@ -0,0 +1,274 @@
+// Package beacontest runs a beacon in memory for tests. The server is the
+// full fx graph of the beacon served over bufconn, so tests need neither
+// network ports nor sleeps, and they script the faults, health statuses and
+// identities the code under test observes.
+package beacontest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/troydai/grpcbeacon/internal/certgen"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+	"github.com/troydai/grpcbeacon/pkg/beaconclient"
+	"github.com/troydai/grpcbeacon/pkg/beaconserver"
+)
+
+const (
+	// ServerName is the name the server certificate is issued for.
+	ServerName = "beacon.test"
+
+	_bufferSize = 1 << 20
+	_timeout    = 10 * time.Second
+)
+
+type (
+	// Option configures New.
+	Option func(*options)
+
+	options struct {
+		config    beaconserver.Configuration
+		hostName  string
+		identity  *Identity
+		logger    *zap.Logger
+		registers []beaconserver.Register
+	}
+
+	// Identity is the subject of the client certificate presented by the
+	// connections of the server, which is then served over mutual TLS.
+	Identity struct {
+		CommonName string
+		// SANs are DNS names, IP addresses, email addresses or URIs, e.g.
+		// spiffe://example.org/frontend.
+		SANs []string
+	}
+)
+
+// WithConfig is the configuration of the server. Its listeners and TLS
+// settings are replaced by the in-memory listener.
+func WithConfig(c beaconserver.Configuration) Option {
+	return func(o *options) { o.config = c }
+}
+
+// WithName sets the name of the beacon, beacontest by default.
+func WithName(name string) Option {
+	return func(o *options) { o.config.Name = name }
+}
+
+// WithHostName sets the host name reported by the beacon, beacontest by
+// default.
+func WithHostName(name string) Option {
+	return func(o *options) { o.hostName = name }
+}
+
+// WithIdentity serves the beacon over mutual TLS and connects with a client
+// certificate for id. The certificates are issued by a CA generated for the
+// test.
+func WithIdentity(id Identity) Option {
+	return func(o *options) { o.identity = &id }
+}
+
+// WithLogger replaces the logger of the server, which discards the logs by
+// default.
+func WithLogger(logger *zap.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithRegisters adds services to the server.
+func WithRegisters(registers ...beaconserver.Register) Option {
+	return func(o *options) { o.registers = append(o.registers, registers...) }
+}
+
+// Server is a beacon running in memory.
+type Server struct {
+	lis      *bufconn.Listener
+	creds    credentials.TransportCredentials
+	conn     *grpc.ClientConn
+	registry *health.Registry
+	faults   *faults
+}
+
+// New starts a server and connects to it. The server and the connection
+// are closed when the test ends.
+func New(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	o := options{
+		config:   beaconserver.Configuration{Name: "beacontest"},
+		hostName: "beacontest",
+		logger:   zap.NewNop(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := &Server{
+		lis:    bufconn.Listen(_bufferSize),
+		creds:  insecure.NewCredentials(),
+		faults: &faults{},
+	}
+
+	config := o.config
+	config.Listeners = []settings.Listener{{Name: "bufconn", Address: "bufconn"}}
+	config.AddressFile = ""
+	if o.identity != nil {
+		tlsConfig, creds, err := issue(t.TempDir(), *o.identity)
+		if err != nil {
+			t.Fatalf("fail to issue certificates: %v", err)
+		}
+		config.Listeners[0].TLS = tlsConfig
+		s.creds = creds
+	}
+
+	server, err := beaconserver.New(
+		beaconserver.WithConfig(config),
+		beaconserver.WithEnvironment(beaconserver.Environment{HostName: o.hostName}),
+		beaconserver.WithLogger(o.logger),
+		beaconserver.WithRegisters(o.registers...),
+		beaconserver.WithUnaryInterceptors(s.faults.unary),
+		beaconserver.WithStreamInterceptors(s.faults.stream),
+		beaconserver.WithFxOptions(
+			fx.Provide(func() rpc.ListenFunc {
+				return func(settings.Listener) (net.Listener, error) { return s.lis, nil }
+			}),
+			fx.Populate(&s.registry),
+		),
+	)
+	if err != nil {
+		t.Fatalf("fail to create server: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), _timeout)
+	defer cancel()
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("fail to start server: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), _timeout)
+		defer cancel()
+		if err := server.Stop(ctx); err != nil {
+			t.Errorf("fail to stop server: %v", err)
+		}
+	})
+
+	s.conn = s.Dial(t)
+	s.conn.Connect()
+	return s
+}
+
+// Conn returns the connection to the server.
+func (s *Server) Conn() *grpc.ClientConn {
+	return s.conn
+}
+
+// Client returns a beacon client over Conn.
+func (s *Server) Client() *beaconclient.Client {
+	return beaconclient.NewClient(s.conn)
+}
+
+// Dial opens another connection to the server, with the identity of the
+// server if any. It is closed when the test ends.
+func (s *Server) Dial(t testing.TB, opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn", append([]grpc.DialOption{
+		grpc.WithContextDialer(s.dial),
+		grpc.WithTransportCredentials(s.creds),
+	}, opts...)...)
+	if err != nil {
+		t.Fatalf("fail to connect: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+// Dialer connects to the server, for connections made by the code under
+// test, e.g. through grpc.WithContextDialer. It does not add the identity.
+func (s *Server) Dialer() func(context.Context, string) (net.Conn, error) {
+	return s.dial
+}
+
+func (s *Server) dial(ctx context.Context, _ string) (net.Conn, error) {
+	return s.lis.DialContext(ctx)
+}
+
+// SetServingStatus sets the status the health service reports for the
+// service, where the empty name is the overall status. The watchers of the
+// service are notified.
+func (s *Server) SetServingStatus(service string, status healthpb.HealthCheckResponse_ServingStatus) {
+	s.registry.SetServingStatus(service, status)
+}
+
+// SetFault injects f in every beacon call until ClearFaults.
+func (s *Server) SetFault(f Fault) {
+	s.faults.set(f)
+}
+
+// ScriptFaults injects the faults in the next beacon calls, one per call, in
+// order. The calls after the script get the fault of SetFault, if any.
+func (s *Server) ScriptFaults(faults ...Fault) {
+	s.faults.add(faults...)
+}
+
+// ClearFaults drops the scripted faults and the one of SetFault.
+func (s *Server) ClearFaults() {
+	s.faults.clear()
+}
+
+// issue writes the certificates of a CA, the server and the client of id
+// to dir, and returns the TLS configuration of the listener and the
+// credentials of the client.
+func issue(dir string, id Identity) (*settings.TLSConfiguration, credentials.TransportCredentials, error) {
+	ca, err := certgen.NewCA(certgen.Options{CommonName: "beacontest CA"})
+	if err != nil {
+		return nil, nil, err
+	}
+	server, err := ca.Issue(certgen.Options{CommonName: ServerName, SANs: []string{ServerName}})
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := ca.Issue(certgen.Options{CommonName: id.CommonName, SANs: id.SANs, Usage: certgen.UsageClient})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ca.WriteFiles(dir, "ca"); err != nil {
+		return nil, nil, err
+	}
+	if err := server.WriteFiles(dir, "server"); err != nil {
+		return nil, nil, err
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+	creds := credentials.NewTLS(&tls.Config{
+		RootCAs:      roots,
+		Certificates: []tls.Certificate{client.TLSCertificate()},
+		ServerName:   ServerName,
+		MinVersion:   tls.VersionTLS12,
+	})
+
+	return &settings.TLSConfiguration{
+		Enabled:          true,
+		KeyFilePath:      filepath.Join(dir, "server.key.pem"),
+		CertFilePath:     filepath.Join(dir, "server.crt.pem"),
+		ClientCAFilePath: filepath.Join(dir, "ca.crt.pem"),
+		ReloadInterval:   -1,
+	}, creds, nil
+}
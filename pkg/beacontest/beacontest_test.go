@@ -0,0 +1,150 @@
+package beacontest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	testgrpc "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/pkg/beaconclient"
+	"github.com/troydai/grpcbeacon/pkg/beaconserver"
+	"github.com/troydai/grpcbeacon/pkg/beacontest"
+)
+
+func TestServer(t *testing.T) {
+	server := beacontest.New(t, beacontest.WithName("alpha"), beacontest.WithHostName("alpha-host"))
+	client := server.Client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.Signal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "alpha", resp.BeaconName)
+	assert.Equal(t, "alpha-host", resp.Hostname)
+	assert.Nil(t, resp.GetRequest().GetPeer().GetTls())
+}
+
+func TestServerFaults(t *testing.T) {
+	server := beacontest.New(t)
+	client := server.Client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	code := func() codes.Code {
+		_, err := client.Signal(ctx)
+		return status.Code(err)
+	}
+
+	server.SetFault(beacontest.Fault{Code: codes.Unavailable, Message: "down"})
+	_, err := client.Signal(ctx)
+	assert.Equal(t, "down", status.Convert(err).Message())
+	assert.Equal(t, codes.Unavailable, code())
+	_, err = client.Signal(metadata.AppendToOutgoingContext(ctx, beacon.MetadataStatusCode, "RESOURCE_EXHAUSTED"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "the metadata of the caller takes precedence")
+
+	server.ScriptFaults(beacontest.Fault{Code: codes.Internal}, beacontest.Fault{})
+	assert.Equal(t, codes.Internal, code())
+	assert.Equal(t, codes.OK, code())
+	assert.Equal(t, codes.Unavailable, code())
+
+	server.ClearFaults()
+	assert.Equal(t, codes.OK, code())
+
+	server.SetFault(beacontest.Fault{Code: codes.Internal, FailureProbability: proto.Float64(0)})
+	assert.Equal(t, codes.OK, code(), "a zero probability never fails")
+
+	server.SetFault(beacontest.Fault{Hang: true})
+	shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Signal(shortCtx)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	server.ClearFaults()
+	server.ScriptFaults(beacontest.Fault{Code: codes.Aborted})
+	err = client.SignalStream(ctx, 2, 0, func(*beaconclient.SignalResponse) error { return nil })
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = client.Check(ctx, "")
+	assert.NoError(t, err, "health calls are not affected")
+}
+
+func TestServerHealth(t *testing.T) {
+	server := beacontest.New(t)
+	client := server.Client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watch, err := client.Health().Watch(ctx, &healthpb.HealthCheckRequest{Service: "readiness"})
+	require.NoError(t, err)
+	resp, err := watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	server.SetServingStatus("readiness", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	resp, err = watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+	got, err := client.Check(ctx, "readiness")
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, got)
+}
+
+// tlsService fails the calls whose peer is not described by
+// credentials.TLSInfo, as authorization interceptors expect.
+type tlsService struct {
+	testgrpc.UnimplementedTestServiceServer
+}
+
+func (tlsService) EmptyCall(ctx context.Context, _ *testgrpc.Empty) (*testgrpc.Empty, error) {
+	p, _ := peer.FromContext(ctx)
+	if _, ok := p.AuthInfo.(credentials.TLSInfo); !ok {
+		return nil, status.Errorf(codes.Internal, "unexpected auth info %T", p.AuthInfo)
+	}
+	return &testgrpc.Empty{}, nil
+}
+
+func TestServerIdentity(t *testing.T) {
+	server := beacontest.New(t,
+		beacontest.WithIdentity(beacontest.Identity{
+			CommonName: "frontend",
+			SANs:       []string{"spiffe://example.org/frontend"},
+		}),
+		beacontest.WithRegisters(beaconserver.RegisterFunc(func(s grpc.ServiceRegistrar) error {
+			testgrpc.RegisterTestServiceServer(s, tlsService{})
+			return nil
+		})),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := pb.NewBeaconServiceClient(server.Conn()).Signal(ctx, &pb.SignalRequest{})
+	require.NoError(t, err)
+
+	tls := resp.GetRequest().GetPeer().GetTls()
+	require.NotNil(t, tls)
+	assert.Equal(t, "frontend", tls.GetClientIdentity().GetCommonName())
+	assert.Equal(t, "spiffe://example.org/frontend", tls.GetClientIdentity().GetSpiffeId())
+	assert.Equal(t, []string{beacontest.ServerName}, tls.GetServerCertificate().GetDnsNames())
+
+	_, err = testgrpc.NewTestServiceClient(server.Conn()).EmptyCall(ctx, &testgrpc.Empty{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,126 @@
+package beacontest
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/beacon"
+)
+
+// Fault is injected in the beacon calls as if the caller had set the
+// x-beacon-* metadata, so it overrides the fault of the request while the
+// metadata set by the caller takes precedence. The zero Fault leaves the
+// call untouched.
+type Fault struct {
+	Delay time.Duration
+	// Code fails the call when it is not OK.
+	Code codes.Code
+	// FailureProbability is the chance of failing with Code, 1 when nil. An
+	// explicit zero never fails.
+	FailureProbability *float64
+	Message            string
+	// Hang blocks the call until its deadline.
+	Hang bool
+}
+
+func (f Fault) metadata() metadata.MD {
+	md := metadata.MD{}
+	if f.Delay > 0 {
+		md.Set(beacon.MetadataDelay, f.Delay.String())
+	}
+	if f.Code != codes.OK {
+		md.Set(beacon.MetadataStatusCode, strconv.Itoa(int(f.Code)))
+	}
+	if f.FailureProbability != nil {
+		md.Set(beacon.MetadataFailureProbability, strconv.FormatFloat(*f.FailureProbability, 'g', -1, 64))
+	}
+	if f.Message != "" {
+		md.Set(beacon.MetadataErrorMessage, f.Message)
+	}
+	if f.Hang {
+		md.Set(beacon.MetadataHang, "true")
+	}
+
+	return md
+}
+
+// faults hands out the fault of every beacon call: the scripted ones first,
+// one per call, then the persistent one.
+type faults struct {
+	mu       sync.Mutex
+	script   []Fault
+	fallback Fault
+}
+
+func (f *faults) set(fault Fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fallback = fault
+}
+
+func (f *faults) add(script ...Fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.script = append(f.script, script...)
+}
+
+func (f *faults) clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.script, f.fallback = nil, Fault{}
+}
+
+func (f *faults) next() Fault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.script) == 0 {
+		return f.fallback
+	}
+
+	next := f.script[0]
+	f.script = f.script[1:]
+	return next
+}
+
+// inject adds the next fault to the metadata of the beacon calls. The other
+// services, e.g. health, are not affected.
+func (f *faults) inject(ctx context.Context, method string) context.Context {
+	if !strings.HasPrefix(method, "/"+pb.BeaconService_ServiceDesc.ServiceName+"/") {
+		return ctx
+	}
+
+	injected := f.next().metadata()
+	md, _ := metadata.FromIncomingContext(ctx)
+	for k, v := range md {
+		injected[k] = v
+	}
+
+	return metadata.NewIncomingContext(ctx, injected)
+}
+
+func (f *faults) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(f.inject(ctx, info.FullMethod), req)
+}
+
+func (f *faults) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &faultStream{ServerStream: ss, ctx: f.inject(ss.Context(), info.FullMethod)})
+}
+
+type faultStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *faultStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+func TestIntegration_Shutdown(t *testing.T) {
+	newApp := func(t *testing.T, drainPeriod time.Duration) (*fxtest.App, *grpc.ClientConn) {
+		testConfig := settings.Configuration{
+			Name:     "shutdown-beacon",
+			Address:  "127.0.0.1",
+			Shutdown: &settings.Shutdown{DrainPeriod: drainPeriod},
+		}
+
+		var server *rpc.Server
+		app := fxtest.New(t,
+			fx.Populate(&server),
+			fx.Provide(func() settings.Configuration { return testConfig }),
+			fx.Provide(func() settings.Environment { return settings.Environment{HostName: "shutdown-host"} }),
+			logging.Module,
+			rpc.Module,
+			beacon.Module,
+			health.Module,
+		)
+
+		startCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, app.Start(startCtx))
+
+		port := server.Addrs()[0].(*net.TCPAddr).Port
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("127.0.0.1:%d", port),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, conn.Close()) })
+
+		return app, conn
+	}
+
+	t.Run("reports not serving while draining", func(t *testing.T) {
+		app, conn := newApp(t, 500*time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		watch, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		resp, err := watch.Recv()
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+		stopped := make(chan error, 1)
+		go func() {
+			stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			stopped <- app.Stop(stopCtx)
+		}()
+
+		resp, err = watch.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+		// Calls are still served during the drain period.
+		_, err = pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{})
+		assert.NoError(t, err)
+
+		assert.NoError(t, <-stopped)
+	})
+
+	t.Run("ends open streams after draining", func(t *testing.T) {
+		app, conn := newApp(t, 100*time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		watch, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		_, err = watch.Recv()
+		require.NoError(t, err)
+
+		signals, err := pb.NewBeaconServiceClient(conn).SignalStream(ctx, &pb.SignalStreamRequest{})
+		require.NoError(t, err)
+		_, err = signals.Recv()
+		require.NoError(t, err)
+
+		pings, err := pb.NewBeaconServiceClient(conn).Ping(ctx)
+		require.NoError(t, err)
+		require.NoError(t, pings.Send(&pb.PingRequest{Sequence: 1}))
+		_, err = pings.Recv()
+		require.NoError(t, err)
+
+		start := time.Now()
+		stopCtx, cancelStop := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelStop()
+		require.NoError(t, app.Stop(stopCtx), "the graceful stop completes with the streams open")
+		assert.Less(t, time.Since(start), 2*time.Second)
+
+		resp, err := watch.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+		_, err = watch.Recv()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		for {
+			if _, err = signals.Recv(); err != nil {
+				break
+			}
+		}
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		_, err = pings.Recv()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("falls back to hard stop", func(t *testing.T) {
+		app, conn := newApp(t, 0)
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), beacon.MetadataHang, "true")
+		called := make(chan error, 1)
+		go func() {
+			_, err := pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{})
+			called <- err
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		stopCtx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, app.Stop(stopCtx), context.DeadlineExceeded)
+
+		select {
+		case err := <-called:
+			assert.Equal(t, codes.Unavailable, status.Code(err))
+		case <-time.After(5 * time.Second):
+			t.Fatal("hanging call was not terminated by the hard stop")
+		}
+	})
+}
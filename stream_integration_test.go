@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+func TestIntegration_Streaming(t *testing.T) {
+	testConfig := settings.Configuration{
+		Name:    "stream-beacon",
+		Address: "127.0.0.1",
+	}
+
+	testEnv := settings.Environment{
+		HostName: "stream-host",
+	}
+
+	var server *rpc.Server
+	app := fxtest.New(t,
+		fx.Populate(&server),
+		fx.Provide(func() settings.Configuration { return testConfig }),
+		fx.Provide(func() settings.Environment { return testEnv }),
+		logging.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+
+	startCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Start(startCtx))
+
+	port := server.Addrs()[0].(*net.TCPAddr).Port
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("127.0.0.1:%d", port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+
+	client := pb.NewBeaconServiceClient(conn)
+
+	t.Run("stream ends after count", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		stream, err := client.SignalStream(ctx, &pb.SignalStreamRequest{
+			Interval: durationpb.New(10 * time.Millisecond),
+			Count:    3,
+		})
+		require.NoError(t, err)
+
+		for i := uint64(1); i <= 3; i++ {
+			resp, err := stream.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, i, resp.Sequence)
+			assert.Contains(t, resp.Reply, "Beacon signal at")
+			assert.Equal(t, "stream-host", resp.Details["Hostname"])
+			assert.Equal(t, "stream-beacon", resp.Details["BeaconName"])
+		}
+
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("unbounded stream ends on cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		stream, err := client.SignalStream(ctx, &pb.SignalStreamRequest{
+			Interval: durationpb.New(10 * time.Millisecond),
+		})
+		require.NoError(t, err)
+
+		for i := uint64(1); i <= 5; i++ {
+			resp, err := stream.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, i, resp.Sequence)
+		}
+
+		cancel()
+		for {
+			if _, err = stream.Recv(); err != nil {
+				break
+			}
+		}
+		assert.Equal(t, codes.Canceled, status.Code(err))
+	})
+
+	t.Run("negative interval is rejected", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		stream, err := client.SignalStream(ctx, &pb.SignalStreamRequest{
+			Interval: durationpb.New(-time.Second),
+		})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ping echoes sequence with server timestamps", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		stream, err := client.Ping(ctx)
+		require.NoError(t, err)
+
+		for i := uint64(1); i <= 3; i++ {
+			sent := time.Now()
+			require.NoError(t, stream.Send(&pb.PingRequest{
+				Sequence:       i,
+				ClientSendTime: timestamppb.New(sent),
+			}))
+
+			resp, err := stream.Recv()
+			require.NoError(t, err)
+			received := time.Now()
+
+			assert.Equal(t, i, resp.Sequence)
+			assert.True(t, resp.ClientSendTime.AsTime().Equal(sent))
+
+			serverRecv := resp.ServerReceiveTime.AsTime()
+			serverSend := resp.ServerSendTime.AsTime()
+			assert.False(t, serverSend.Before(serverRecv))
+			assert.LessOrEqual(t, serverSend.Sub(serverRecv), received.Sub(sent))
+		}
+
+		require.NoError(t, stream.CloseSend())
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Stop(stopCtx))
+}
@@ -0,0 +1,474 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/certgen"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+)
+
+func TestIntegration_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	serverCert := issueTestCert(t, ca, certgen.Options{CommonName: "localhost", SANs: []string{"localhost"}})
+	require.NoError(t, serverCert.WriteFiles(dir, "server"))
+	require.NoError(t, ca.WriteFiles(dir, "ca"))
+
+	allowed := issueTestCert(t, ca, certgen.Options{CommonName: "frontend", SANs: []string{"spiffe://beacon.test/frontend"}, Usage: certgen.UsageClient})
+	denied := issueTestCert(t, ca, certgen.Options{CommonName: "intruder", SANs: []string{"spiffe://beacon.test/intruder"}, Usage: certgen.UsageClient})
+
+	start := func(t *testing.T, clientAuth string) int {
+		return startTLSBeacon(t, &settings.TLSConfiguration{
+			Enabled:          true,
+			KeyFilePath:      filepath.Join(dir, "server.key.pem"),
+			CertFilePath:     filepath.Join(dir, "server.crt.pem"),
+			ClientCAFilePath: filepath.Join(dir, "ca.crt.pem"),
+			ClientAuth:       clientAuth,
+			AllowedSPIFFEIDs: []string{"spiffe://beacon.test/frontend"},
+		})
+	}
+
+	signal := func(t *testing.T, port int, clientCert *certgen.Certificate) (*pb.SignalResponse, error) {
+		tlsConfig := &tls.Config{
+			RootCAs:    testCertPool(ca),
+			ServerName: "localhost",
+		}
+		if clientCert != nil {
+			tlsConfig.Certificates = []tls.Certificate{clientCert.TLSCertificate()}
+		}
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("127.0.0.1:%d", port),
+			grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, conn.Close()) }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		return pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{Message: "hello"})
+	}
+
+	t.Run("require", func(t *testing.T) {
+		port := start(t, rpc.ClientAuthRequire)
+
+		resp, err := signal(t, port, allowed)
+		require.NoError(t, err)
+		id := resp.GetRequest().GetPeer().GetTls().GetClientIdentity()
+		require.NotNil(t, id)
+		assert.Equal(t, "frontend", id.GetCommonName())
+		assert.Equal(t, "spiffe://beacon.test/frontend", id.GetSpiffeId())
+		assert.Contains(t, id.GetSans(), "spiffe://beacon.test/frontend")
+
+		_, err = signal(t, port, denied)
+		assert.Error(t, err, "certificate outside of the allowlist must be rejected")
+
+		_, err = signal(t, port, nil)
+		assert.Error(t, err, "missing client certificate must be rejected")
+	})
+
+	t.Run("verify-if-given", func(t *testing.T) {
+		port := start(t, rpc.ClientAuthVerifyIfGiven)
+
+		resp, err := signal(t, port, nil)
+		require.NoError(t, err)
+		assert.Nil(t, resp.GetRequest().GetPeer().GetTls().GetClientIdentity())
+
+		resp, err = signal(t, port, allowed)
+		require.NoError(t, err)
+		assert.Equal(t, "spiffe://beacon.test/frontend", resp.GetRequest().GetPeer().GetTls().GetClientIdentity().GetSpiffeId())
+
+		_, err = signal(t, port, denied)
+		assert.Error(t, err)
+	})
+}
+
+func TestIntegration_TLSReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	first := issueTestCert(t, ca, certgen.Options{CommonName: "localhost", SANs: []string{"localhost"}})
+	second := issueTestCert(t, ca, certgen.Options{CommonName: "localhost", SANs: []string{"localhost"}})
+	require.NoError(t, first.WriteFiles(dir, "server"))
+
+	port := startTLSBeacon(t, &settings.TLSConfiguration{
+		Enabled:        true,
+		KeyFilePath:    filepath.Join(dir, "server.key.pem"),
+		CertFilePath:   filepath.Join(dir, "server.crt.pem"),
+		ReloadInterval: 20 * time.Millisecond,
+	})
+
+	// servedSerial makes a call over a new connection and returns the serial
+	// of the certificate the server presented.
+	servedSerial := func() *big.Int {
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("127.0.0.1:%d", port),
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: testCertPool(ca), ServerName: "localhost"})),
+		)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, conn.Close()) }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var p peer.Peer
+		_, err = pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{Message: "hello"}, grpc.Peer(&p))
+		require.NoError(t, err)
+
+		return p.AuthInfo.(credentials.TLSInfo).State.PeerCertificates[0].SerialNumber
+	}
+
+	firstSerial := first.Cert.SerialNumber
+	secondSerial := second.Cert.SerialNumber
+	assert.Equal(t, firstSerial, servedSerial())
+
+	// The connection opened before the reload keeps the first certificate.
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("127.0.0.1:%d", port),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: testCertPool(ca), ServerName: "localhost"})),
+	)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+	reportedSerial := func() string {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{Message: "hello"})
+		require.NoError(t, err)
+		return resp.GetRequest().GetPeer().GetTls().GetServerCertificate().GetSerial()
+	}
+	assert.Equal(t, firstSerial.String(), reportedSerial())
+
+	// A certificate that is half written is rejected.
+	certPEM := second.CertPEM()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "server.crt.pem"), certPEM[:len(certPEM)/2], 0o600))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, firstSerial, servedSerial())
+
+	require.NoError(t, second.WriteFiles(dir, "server"))
+	assert.Eventually(t, func() bool {
+		return servedSerial().Cmp(secondSerial) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, firstSerial.String(), reportedSerial())
+}
+
+func TestIntegration_TLSServerNameSelection(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	fallback := issueTestCert(t, ca, certgen.Options{CommonName: "fallback", SANs: []string{"fallback.test"}})
+	alpha := issueTestCert(t, ca, certgen.Options{CommonName: "alpha", SANs: []string{"alpha.test"}})
+	beta := issueTestCert(t, ca, certgen.Options{CommonName: "beta", SANs: []string{"beta.test"}})
+	require.NoError(t, fallback.WriteFiles(dir, "fallback"))
+	require.NoError(t, alpha.WriteFiles(dir, "alpha"))
+	require.NoError(t, beta.WriteFiles(dir, "beta"))
+
+	port := startTLSBeacon(t, &settings.TLSConfiguration{
+		Enabled: true,
+		Certificates: []settings.CertificateConfiguration{
+			{
+				// Served for its DNS SANs.
+				KeyFilePath:  filepath.Join(dir, "alpha.key.pem"),
+				CertFilePath: filepath.Join(dir, "alpha.crt.pem"),
+			},
+			{
+				KeyFilePath:  filepath.Join(dir, "beta.key.pem"),
+				CertFilePath: filepath.Join(dir, "beta.crt.pem"),
+				ServerNames:  []string{"*.beta.test"},
+			},
+			{
+				KeyFilePath:  filepath.Join(dir, "fallback.key.pem"),
+				CertFilePath: filepath.Join(dir, "fallback.crt.pem"),
+				Default:      true,
+			},
+		},
+	})
+
+	cases := []struct {
+		serverName string
+		expected   *certgen.Certificate
+	}{
+		{serverName: "alpha.test", expected: alpha},
+		{serverName: "ALPHA.test", expected: alpha},
+		{serverName: "one.beta.test", expected: beta},
+		{serverName: "two.one.beta.test", expected: fallback},
+		{serverName: "beta.test", expected: fallback},
+		{serverName: "unknown.test", expected: fallback},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.serverName, func(t *testing.T) {
+			// The fallback certificate does not cover every name, so the
+			// client checks the served certificate itself.
+			conn, err := grpc.NewClient(
+				fmt.Sprintf("127.0.0.1:%d", port),
+				grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+					ServerName:         tc.serverName,
+					InsecureSkipVerify: true,
+				})),
+			)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, conn.Close()) }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			var p peer.Peer
+			resp, err := pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{Message: "hello"}, grpc.Peer(&p))
+			require.NoError(t, err)
+
+			served := p.AuthInfo.(credentials.TLSInfo).State.PeerCertificates[0]
+			assert.Equal(t, tc.expected.Cert.SerialNumber, served.SerialNumber)
+
+			reported := resp.GetRequest().GetPeer().GetTls().GetServerCertificate()
+			require.NotNil(t, reported)
+			assert.Equal(t, tc.expected.Cert.SerialNumber.String(), reported.GetSerial())
+			assert.Equal(t, tc.expected.Cert.Subject.String(), reported.GetSubject())
+			assert.Equal(t, tc.expected.Cert.DNSNames, reported.GetDnsNames())
+		})
+	}
+}
+
+func TestIntegration_TLSPolicy(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	server := issueTestCert(t, ca, certgen.Options{CommonName: "localhost", SANs: []string{"localhost"}})
+	require.NoError(t, server.WriteFiles(dir, "server"))
+
+	signal := func(t *testing.T, port int, clientConfig *tls.Config) (*pb.SignalResponse, error) {
+		clientConfig.RootCAs = testCertPool(ca)
+		clientConfig.ServerName = "localhost"
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("127.0.0.1:%d", port),
+			grpc.WithTransportCredentials(credentials.NewTLS(clientConfig)),
+		)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, conn.Close()) }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		return pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{Message: "hello"})
+	}
+
+	t.Run("TLS 1.3 only", func(t *testing.T) {
+		port := startTLSBeacon(t, &settings.TLSConfiguration{
+			Enabled:       true,
+			KeyFilePath:   filepath.Join(dir, "server.key.pem"),
+			CertFilePath:  filepath.Join(dir, "server.crt.pem"),
+			MinVersion:    "1.3",
+			ALPNProtocols: []string{"beacon/1"},
+		})
+
+		resp, err := signal(t, port, &tls.Config{})
+		require.NoError(t, err)
+		assert.Equal(t, "TLS 1.3", resp.GetRequest().GetPeer().GetTls().GetVersion())
+		assert.Equal(t, "h2", resp.GetRequest().GetPeer().GetTls().GetNegotiatedProtocol())
+
+		_, err = signal(t, port, &tls.Config{MaxVersion: tls.VersionTLS12})
+		assert.Error(t, err)
+	})
+
+	t.Run("restricted cipher suites", func(t *testing.T) {
+		port := startTLSBeacon(t, &settings.TLSConfiguration{
+			Enabled:          true,
+			KeyFilePath:      filepath.Join(dir, "server.key.pem"),
+			CertFilePath:     filepath.Join(dir, "server.crt.pem"),
+			MaxVersion:       "1.2",
+			CipherSuites:     []string{"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256"},
+			CurvePreferences: []string{"P-384"},
+		})
+
+		resp, err := signal(t, port, &tls.Config{})
+		require.NoError(t, err)
+		assert.Equal(t, "TLS 1.2", resp.GetRequest().GetPeer().GetTls().GetVersion())
+		assert.Equal(t, "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256", resp.GetRequest().GetPeer().GetTls().GetCipherSuite())
+
+		_, err = signal(t, port, &tls.Config{CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}})
+		assert.Error(t, err)
+	})
+}
+
+func TestIntegration_EphemeralTLS(t *testing.T) {
+	port := startTLSBeacon(t, &settings.TLSConfiguration{
+		Enabled:   true,
+		Ephemeral: true,
+	})
+
+	// The certificate is only known at runtime, so the client pins the
+	// certificate it was presented.
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("127.0.0.1:%d", port),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})),
+	)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var p peer.Peer
+	resp, err := pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{Message: "hello"}, grpc.Peer(&p))
+	require.NoError(t, err)
+
+	served := p.AuthInfo.(credentials.TLSInfo).State.PeerCertificates[0]
+	assert.NoError(t, served.VerifyHostname("localhost"))
+	assert.NoError(t, served.VerifyHostname("127.0.0.1"))
+	assert.NoError(t, served.CheckSignature(served.SignatureAlgorithm, served.RawTBSCertificate, served.Signature), "the certificate is self-signed")
+	assert.Equal(t, served.SerialNumber.String(), resp.GetRequest().GetPeer().GetTls().GetServerCertificate().GetSerial())
+}
+
+func TestIntegration_TLSMisbehavior(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	require.NoError(t, ca.WriteFiles(dir, "ca"))
+
+	cases := []struct {
+		mode     string
+		errorMsg string
+		check    func(t *testing.T, served []*x509.Certificate)
+	}{
+		{
+			mode:     settings.MisbehaviorExpired,
+			errorMsg: "certificate has expired or is not yet valid",
+			check: func(t *testing.T, served []*x509.Certificate) {
+				assert.True(t, served[0].NotAfter.Before(time.Now()))
+			},
+		},
+		{
+			mode:     settings.MisbehaviorNotYetValid,
+			errorMsg: "certificate has expired or is not yet valid",
+			check: func(t *testing.T, served []*x509.Certificate) {
+				assert.True(t, served[0].NotBefore.After(time.Now()))
+			},
+		},
+		{
+			mode:     settings.MisbehaviorWrongSAN,
+			errorMsg: "certificate is valid for wrong-name.invalid, not localhost",
+			check: func(t *testing.T, served []*x509.Certificate) {
+				assert.Equal(t, []string{"wrong-name.invalid"}, served[0].DNSNames)
+			},
+		},
+		{
+			mode:     settings.MisbehaviorSelfSigned,
+			errorMsg: "certificate signed by unknown authority",
+			check: func(t *testing.T, served []*x509.Certificate) {
+				assert.Equal(t, served[0].Subject, served[0].Issuer)
+			},
+		},
+		{
+			mode:     settings.MisbehaviorIncompleteChain,
+			errorMsg: "certificate signed by unknown authority",
+			check: func(t *testing.T, served []*x509.Certificate) {
+				require.Len(t, served, 1)
+				assert.Equal(t, "grpcbeacon misbehavior intermediate CA", served[0].Issuer.CommonName)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.mode, func(t *testing.T) {
+			port := startTLSBeacon(t, &settings.TLSConfiguration{
+				Enabled: true,
+				Misbehavior: &settings.TLSMisbehavior{
+					Mode:           tc.mode,
+					CACertFilePath: filepath.Join(dir, "ca.crt.pem"),
+					CAKeyFilePath:  filepath.Join(dir, "ca.key.pem"),
+				},
+			})
+			addr := fmt.Sprintf("127.0.0.1:%d", port)
+
+			_, err := tls.Dial("tcp", addr, &tls.Config{
+				RootCAs:    testCertPool(ca),
+				ServerName: "localhost",
+				NextProtos: []string{"h2"},
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorMsg)
+
+			conn, err := tls.Dial("tcp", addr, &tls.Config{
+				InsecureSkipVerify: true,
+				ServerName:         "localhost",
+				NextProtos:         []string{"h2"},
+			})
+			require.NoError(t, err)
+			defer func() { require.NoError(t, conn.Close()) }()
+
+			tc.check(t, conn.ConnectionState().PeerCertificates)
+		})
+	}
+}
+
+// startTLSBeacon starts the beacon with the TLS configuration on a free
+// port and returns the port. The beacon is stopped when the test ends.
+func startTLSBeacon(t *testing.T, tlsConfig *settings.TLSConfiguration) int {
+	testConfig := settings.Configuration{
+		Name:    "test-beacon",
+		Address: "127.0.0.1",
+		TLS:     tlsConfig,
+	}
+
+	var server *rpc.Server
+	app := fxtest.New(t,
+		fx.Populate(&server),
+		fx.Provide(func() settings.Configuration { return testConfig }),
+		fx.Provide(func() settings.Environment { return settings.Environment{HostName: "test-host"} }),
+		logging.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+
+	startCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Start(startCtx))
+	t.Cleanup(func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, app.Stop(stopCtx))
+	})
+
+	port := server.Addrs()[0].(*net.TCPAddr).Port
+
+	return port
+}
+
+func newTestCA(t *testing.T) *certgen.Certificate {
+	ca, err := certgen.NewCA(certgen.Options{CommonName: "grpcbeacon test CA"})
+	require.NoError(t, err)
+	return ca
+}
+
+func issueTestCert(t *testing.T, ca *certgen.Certificate, opts certgen.Options) *certgen.Certificate {
+	c, err := ca.Issue(opts)
+	require.NoError(t, err)
+	return c
+}
+
+func testCertPool(ca *certgen.Certificate) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+	return pool
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/troydai/grpcbeacon/gen/go/troydai/grpcbeacon/v1"
+	"github.com/troydai/grpcbeacon/internal/beacon"
+	"github.com/troydai/grpcbeacon/internal/health"
+	"github.com/troydai/grpcbeacon/internal/logging"
+	"github.com/troydai/grpcbeacon/internal/rpc"
+	"github.com/troydai/grpcbeacon/internal/settings"
+	"github.com/troydai/grpcbeacon/internal/tracing"
+)
+
+func TestIntegration_Tracing(t *testing.T) {
+	traceFile := filepath.Join(t.TempDir(), "traces.json")
+
+	testConfig := settings.Configuration{
+		Name:    "tracing-beacon",
+		Address: "127.0.0.1",
+		Tracing: &settings.Tracing{
+			Enabled:  true,
+			Exporter: tracing.ExporterFile,
+			FilePath: traceFile,
+		},
+	}
+
+	var server *rpc.Server
+	app := fxtest.New(t,
+		fx.Populate(&server),
+		fx.Provide(func() settings.Configuration { return testConfig }),
+		fx.Provide(func() settings.Environment { return settings.Environment{HostName: "tracing-host"} }),
+		logging.Module,
+		tracing.Module,
+		rpc.Module,
+		beacon.Module,
+		health.Module,
+	)
+
+	startCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Start(startCtx))
+
+	port := server.Addrs()[0].(*net.TCPAddr).Port
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("127.0.0.1:%d", port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID = "00f067aa0ba902b7"
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx,
+		"traceparent", fmt.Sprintf("00-%s-%s-01", traceID, parentSpanID),
+		"baggage", "tenant=beacon",
+	)
+
+	_, err = pb.NewBeaconServiceClient(conn).Signal(ctx, &pb.SignalRequest{})
+	require.NoError(t, err)
+
+	// Stopping the app flushes the spans to the file.
+	stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, app.Stop(stopCtx))
+
+	data, err := os.ReadFile(traceFile)
+	require.NoError(t, err)
+
+	traces := string(data)
+	assert.Contains(t, traces, `"Name":"troydai.grpcbeacon.v1.BeaconService/Signal"`)
+	assert.Contains(t, traces, fmt.Sprintf(`"TraceID":"%s"`, traceID))
+	assert.Contains(t, traces, fmt.Sprintf(`"SpanID":"%s"`, parentSpanID))
+}